@@ -18,8 +18,10 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"reflect"
+	"strconv"
 	"sync"
 	"time"
 
@@ -33,6 +35,27 @@ type Source interface {
 	Lookup(key string) ([]string, bool)
 }
 
+// Expander can optionally be implemented by a [Source] to rewrite every value
+// before it is converted, including default values taken from struct tags. A
+// typical use is the substitution of variable references.
+type Expander interface {
+	Expand(val string) (string, error)
+}
+
+// expand applies e to each of the values, returning a new slice so that the
+// source's own data is left untouched.
+func expand(e Expander, vals []string) ([]string, error) {
+	out := make([]string, len(vals))
+	for i, v := range vals {
+		x, err := e.Expand(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = x
+	}
+	return out, nil
+}
+
 // Transformer is a function that transforms a struct field name into a key.
 type Transformer func(string) string
 
@@ -68,6 +91,7 @@ func (r *defaultResolver) Resolve(rt reflect.Type) ([]field, error) {
 		f := field{
 			Index:  i,
 			Name:   ft.Name,
+			Type:   ft.Type,
 			Key:    flags.Key,
 			Flags:  flags,
 			Inline: flags.Inline,
@@ -89,6 +113,7 @@ func (r *defaultResolver) Resolve(rt reflect.Type) ([]field, error) {
 		}
 
 		f.Embedded = isEmbedded(ft)
+		f.Indexed = isIndexed(ft)
 
 		if f.Inline && !f.Embedded {
 			return nil, fmt.Errorf(
@@ -126,6 +151,7 @@ func (r *cachingResolver) Resolve(rt reflect.Type) ([]field, error) {
 // Binder extracts values from a generic key-value source into a struct.
 type Binder struct {
 	resolver resolver
+	strict   bool
 }
 
 // New creates a new Binder using the specified struct tag for metadata parsing.
@@ -150,6 +176,7 @@ func New(name string, opts ...Option) *Binder {
 
 	return &Binder{
 		resolver: resolver,
+		strict:   cfg.strict,
 	}
 }
 
@@ -171,27 +198,43 @@ func (b *Binder) Bind[T any](v *T, prefix string, source Source) error {
 	return err
 }
 
+// state summarizes the outcome of binding a struct.
+type state struct {
+	// bound reports whether any field received a value, including defaults.
+	bound bool
+	// found reports whether the source supplied a value for any field.
+	found bool
+}
+
+// merge combines the outcome of binding a nested struct into s.
+func (s *state) merge(o state) {
+	s.bound = s.bound || o.bound
+	s.found = s.found || o.found
+}
+
 // process populates rv from source, reporting whether any field of rv, or of
-// a struct nested within it, received a value.
+// a struct nested within it, received a value, and whether any such value
+// came from the source rather than a default.
 //
-// The caller needs that answer to decide whether an absent optional section
-// should be materialized; see the nested pointer handling below.
+// The caller needs these answers to decide whether an absent optional section
+// should be materialized, and where a sequence of indexed sections ends; see
+// the nested pointer and indexed slice handling below.
 func (b *Binder) process(
 	rv reflect.Value,
 	prefix string,
 	source Source,
-) (bool, error) {
+) (state, error) {
 	fields, err := b.resolver.Resolve(rv.Type())
 	if err != nil {
-		return false, err
+		return state{}, err
 	}
 
 	// Field errors are collected rather than returned at the first one, so a
 	// caller fixing a configuration sees everything that is wrong with it in
 	// one pass instead of one variable per attempt.
 	var (
-		bound bool
-		errs  []error
+		st   state
+		errs []error
 	)
 
 	for _, f := range fields {
@@ -199,29 +242,33 @@ func (b *Binder) process(
 
 		// Inline struct
 		if f.Inline {
-			ok, err := b.nested(fv, prefix, source)
+			sub, err := b.nested(fv, prefix, source)
 			if err != nil {
 				errs = append(errs, err)
 			}
-			bound = bound || ok
+			st.merge(sub)
 			continue
 		}
 
 		key := f.Key
 
 		// Embedded structured prefix
-		if f.Embedded {
+		if f.Embedded || f.Indexed {
 			nested := prefix
 			if f.Flags.Prefix != nil {
 				nested += *f.Flags.Prefix
 			} else {
 				nested += key + "_"
 			}
-			ok, err := b.nested(fv, nested, source)
+			bind := b.nested
+			if f.Indexed {
+				bind = b.indexed
+			}
+			sub, err := bind(fv, nested, source)
 			if err != nil {
 				errs = append(errs, err)
 			}
-			bound = bound || ok
+			st.merge(sub)
 			continue
 		}
 
@@ -235,11 +282,13 @@ func (b *Binder) process(
 			ok = false
 		}
 
-		if !ok {
+		if ok {
+			st.found = true
+		} else {
 			switch {
 			case f.Flags.Default != "":
 				vals = []string{f.Flags.Default}
-			case f.Flags.Required:
+			case f.Flags.Required || (b.strict && !f.Flags.Optional):
 				errs = append(errs, fmt.Errorf(
 					"required key %q is missing", key,
 				))
@@ -249,6 +298,17 @@ func (b *Binder) process(
 			}
 		}
 
+		if e, ok := source.(Expander); ok {
+			expanded, err := expand(e, vals)
+			if err != nil {
+				errs = append(errs, fmt.Errorf(
+					"could not expand key %q: %w", key, err,
+				))
+				continue
+			}
+			vals = expanded
+		}
+
 		if err := setValues(fv, vals, f.Flags); err != nil {
 			errs = append(errs, fmt.Errorf(
 				"could not set field %q from key %q: %w",
@@ -256,10 +316,94 @@ func (b *Binder) process(
 			))
 			continue
 		}
-		bound = true
+		st.bound = true
+	}
+
+	return st, errors.Join(errs...)
+}
+
+// Descriptor describes a key consumed by a [Binder] for a struct field.
+type Descriptor struct {
+	// Key is the fully-qualified key, including all prefixes.
+	Key string
+	// Field is the dot-separated path of the struct field, starting at the
+	// top-level struct.
+	Field string
+	// Type is the Go type of the field.
+	Type reflect.Type
+	// Default is the default value, or an empty string if there is none.
+	Default string
+	// Required indicates whether binding fails if the key is absent.
+	Required bool
+}
+
+// Describe walks the struct type rt exactly like [Binder.Bind] would, but
+// instead of reading values, it reports a [Descriptor] for every key that is
+// consumed. Pointers to structs are dereferenced. This is useful for
+// generating documentation of the expected configuration.
+func (b *Binder) Describe(
+	rt reflect.Type,
+	prefix string,
+) ([]Descriptor, error) {
+	for rt != nil && rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	if rt == nil || rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct type, but got %v", rt)
 	}
+	return b.describe(nil, rt, prefix, "")
+}
 
-	return bound, errors.Join(errs...)
+// describe appends the descriptors of all keys consumed by rt to out.
+func (b *Binder) describe(
+	out []Descriptor,
+	rt reflect.Type,
+	prefix, path string,
+) ([]Descriptor, error) {
+	fields, err := b.resolver.Resolve(rt)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fields {
+		name := path + f.Name
+		if f.Inline || f.Embedded || f.Indexed {
+			nested := prefix
+			if !f.Inline {
+				if f.Flags.Prefix != nil {
+					nested += *f.Flags.Prefix
+				} else {
+					nested += f.Key + "_"
+				}
+			}
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if f.Indexed {
+				// Describe a single element under a placeholder index.
+				nested += "<n>_"
+				name += "[]"
+				ft = ft.Elem()
+				for ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+			}
+			out, err = b.describe(out, ft, nested, name+".")
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		out = append(out, Descriptor{
+			Key:     prefix + f.Key,
+			Field:   name,
+			Type:    f.Type,
+			Default: f.Flags.Default,
+			Required: f.Flags.Default == "" &&
+				(f.Flags.Required || (b.strict && !f.Flags.Optional)),
+		})
+	}
+	return out, nil
 }
 
 // nested processes a struct field, which may be reached through one or more
@@ -273,7 +417,7 @@ func (b *Binder) nested(
 	fv reflect.Value,
 	prefix string,
 	source Source,
-) (bool, error) {
+) (state, error) {
 	if fv.Kind() != reflect.Pointer || !fv.IsNil() {
 		return b.process(pointer.Deref(fv), prefix, source)
 	}
@@ -286,13 +430,13 @@ func (b *Binder) nested(
 	}
 
 	tmp := reflect.New(rt)
-	bound, err := b.process(tmp.Elem(), prefix, source)
-	if err != nil || !bound {
-		return bound, err
+	st, err := b.process(tmp.Elem(), prefix, source)
+	if err != nil || !st.bound {
+		return st, err
 	}
 
 	if !fv.CanSet() {
-		return bound, nil
+		return st, nil
 	}
 
 	// Rebuild the pointer chain the field's type calls for.
@@ -305,16 +449,72 @@ func (b *Binder) nested(
 	}
 	fv.Set(val)
 
-	return bound, nil
+	return st, nil
+}
+
+// indexed processes a slice of structs, whose elements are bound from keys
+// carrying a zero-based index after the prefix (e.g., UPSTREAM_0_HOST,
+// UPSTREAM_1_HOST). Elements are read in order until the first index for
+// which the source supplies no value at all; default values alone do not
+// count, as they would otherwise extend the sequence indefinitely. The
+// slice is left untouched if not even the first element is present.
+func (b *Binder) indexed(
+	fv reflect.Value,
+	prefix string,
+	source Source,
+) (state, error) {
+	fv = pointer.Deref(fv)
+	et := fv.Type().Elem()
+	rt := et
+	for rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+
+	var (
+		st   state
+		errs []error
+	)
+	slice := reflect.MakeSlice(fv.Type(), 0, 0)
+	for i := 0; ; i++ {
+		tmp := reflect.New(rt)
+		sub, err := b.process(
+			tmp.Elem(),
+			prefix+strconv.Itoa(i)+"_",
+			source,
+		)
+		if !sub.found {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		st.merge(sub)
+
+		// Rebuild the pointer chain the element type calls for.
+		val := tmp.Elem()
+		for depth := et; depth.Kind() == reflect.Pointer; depth = depth.Elem() {
+			p := reflect.New(val.Type())
+			p.Elem().Set(val)
+			val = p
+		}
+		slice = reflect.Append(slice, val)
+	}
+
+	if slice.Len() > 0 && fv.CanSet() {
+		fv.Set(slice)
+	}
+	return st, errors.Join(errs...)
 }
 
 type field struct {
 	Index    int
 	Name     string
+	Type     reflect.Type
 	Key      string
 	Flags    *Flags
 	Inline   bool
 	Embedded bool
+	Indexed  bool
 }
 
 // Flags encapsulates the options parsed from a tag.
@@ -322,16 +522,19 @@ type Flags struct {
 	Key      string
 	Prefix   *string
 	Split    string
+	Pairs    string
+	Delim    string
 	Unit     string
 	Format   string
 	Default  string
 	Inline   bool
 	Required bool
+	Optional bool
 }
 
 func parse(s string) (*Flags, error) {
 	t := tag.Parse(s)
-	f := &Flags{Key: t.Name, Split: ","}
+	f := &Flags{Key: t.Name, Split: ",", Delim: ":"}
 
 	seen := make(map[string]bool)
 	for k, v := range t.Opts() {
@@ -345,6 +548,13 @@ func parse(s string) (*Flags, error) {
 			f.Prefix = &v
 		case "split":
 			f.Split = v
+		case "pairs":
+			f.Pairs = v
+		case "delim":
+			if v == "" {
+				return nil, fmt.Errorf("option %q must not be empty", k)
+			}
+			f.Delim = v
 		case "unit":
 			f.Unit = v
 		case "default":
@@ -353,25 +563,48 @@ func parse(s string) (*Flags, error) {
 			f.Inline = true
 		case "required":
 			f.Required = true
+		case "optional":
+			f.Optional = true
 		default:
 			return nil, fmt.Errorf("unknown option: %q", k)
 		}
 		seen[k] = true
 	}
+	if f.Required && f.Optional {
+		return nil, fmt.Errorf(
+			"options %q and %q are mutually exclusive", "required", "optional",
+		)
+	}
 	return f, nil
 }
 
 // isEmbedded checks if a struct field is a true embedded struct that should
 // be processed recursively.
 func isEmbedded(f reflect.StructField) bool {
+	return isStructured(f.Type)
+}
+
+// isIndexed checks if a struct field is a slice of structs whose elements
+// should be processed recursively under indexed prefixes.
+func isIndexed(f reflect.StructField) bool {
 	t := f.Type
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Slice && isStructured(t.Elem())
+}
+
+// isStructured checks if t, after dereferencing pointers, is a struct type
+// made up of individually bound fields, as opposed to a struct type that is
+// converted from a single value (such as [time.Time]).
+func isStructured(t reflect.Type) bool {
 	for t.Kind() == reflect.Pointer {
 		t = t.Elem()
 	}
 	if t.Kind() != reflect.Struct {
 		return false
 	}
-	if t == typeTime || t == typeURL || t == typeLocation {
+	if t == typeTime || t == typeURL || t == typeLocation || t == typeIPNet {
 		return false
 	}
 	if t.Implements(typeTextUnmarshaler) ||
@@ -386,5 +619,6 @@ var (
 	typeDuration        = reflect.TypeFor[time.Duration]()
 	typeLocation        = reflect.TypeFor[time.Location]()
 	typeURL             = reflect.TypeFor[url.URL]()
+	typeIPNet           = reflect.TypeFor[net.IPNet]()
 	typeTextUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
 )
@@ -17,6 +17,7 @@ package bind_test
 import (
 	"encoding"
 	"fmt"
+	"net"
 	"net/url"
 	"reflect"
 	"strings"
@@ -553,6 +554,22 @@ type mockTLocationPtr struct {
 	V *time.Location
 }
 
+type mockTIP struct {
+	V net.IP
+}
+
+type mockTIPSlice struct {
+	V []net.IP
+}
+
+type mockTIPNet struct {
+	V net.IPNet
+}
+
+type mockTIPNetPtr struct {
+	V *net.IPNet
+}
+
 func bindAny(b *bind.Binder, give any, prefix string, src bind.Source) error {
 	switch v := give.(type) {
 	case *mockTBool:
@@ -609,6 +626,14 @@ func bindAny(b *bind.Binder, give any, prefix string, src bind.Source) error {
 		return b.Bind(v, prefix, src)
 	case *mockTLocationPtr:
 		return b.Bind(v, prefix, src)
+	case *mockTIP:
+		return b.Bind(v, prefix, src)
+	case *mockTIPSlice:
+		return b.Bind(v, prefix, src)
+	case *mockTIPNet:
+		return b.Bind(v, prefix, src)
+	case *mockTIPNetPtr:
+		return b.Bind(v, prefix, src)
 	case *mockTNested:
 		return b.Bind(v, prefix, src)
 	case *mockTNestedCustomPrefix:
@@ -692,6 +717,7 @@ func TestBinder_TypeTests(t *testing.T) {
 	t.Parallel()
 
 	u, _ := url.Parse("http://foo.com/bar")
+	_, ipNet, _ := net.ParseCIDR("10.0.0.0/8")
 
 	b := bind.New("bind", bind.WithTransformer(snake.ToUpper))
 
@@ -714,6 +740,48 @@ func TestBinder_TypeTests(t *testing.T) {
 			give: &mockTBool{},
 			want: &mockTBool{true},
 		},
+		{
+			name: "bool yes",
+			vars: map[string]string{"V": "Yes"},
+			give: &mockTBool{},
+			want: &mockTBool{true},
+		},
+		{
+			name: "bool no",
+			vars: map[string]string{"V": "NO"},
+			give: &mockTBool{true},
+			want: &mockTBool{false},
+		},
+		{
+			name: "bool on",
+			vars: map[string]string{"V": "on"},
+			give: &mockTBool{},
+			want: &mockTBool{true},
+		},
+		{
+			name: "bool off",
+			vars: map[string]string{"V": "Off"},
+			give: &mockTBool{true},
+			want: &mockTBool{false},
+		},
+		{
+			name: "bool y",
+			vars: map[string]string{"V": "Y"},
+			give: &mockTBool{},
+			want: &mockTBool{true},
+		},
+		{
+			name: "bool n",
+			vars: map[string]string{"V": "n"},
+			give: &mockTBool{true},
+			want: &mockTBool{false},
+		},
+		{
+			name: "bool mixed case true",
+			vars: map[string]string{"V": "tRuE"},
+			give: &mockTBool{},
+			want: &mockTBool{true},
+		},
 		{
 			name: "int",
 			vars: map[string]string{"V": "42"},
@@ -1173,6 +1241,51 @@ func TestBinder_TypeTests(t *testing.T) {
 			give:    &mockTLocation{},
 			wantErr: true,
 		},
+		{
+			name: "ip v4",
+			vars: map[string]string{"V": "192.168.0.1"},
+			give: &mockTIP{},
+			want: &mockTIP{net.ParseIP("192.168.0.1")},
+		},
+		{
+			name: "ip v6",
+			vars: map[string]string{"V": "::1"},
+			give: &mockTIP{},
+			want: &mockTIP{net.ParseIP("::1")},
+		},
+		{
+			name: "ip slice",
+			vars: map[string]string{"V": "10.0.0.1,10.0.0.2"},
+			give: &mockTIPSlice{},
+			want: &mockTIPSlice{[]net.IP{
+				net.ParseIP("10.0.0.1"),
+				net.ParseIP("10.0.0.2"),
+			}},
+		},
+		{
+			name:    "parse error ip",
+			vars:    map[string]string{"V": "999.0.0.1"},
+			give:    &mockTIP{},
+			wantErr: true,
+		},
+		{
+			name: "ip net",
+			vars: map[string]string{"V": "10.0.0.0/8"},
+			give: &mockTIPNet{},
+			want: &mockTIPNet{*ipNet},
+		},
+		{
+			name: "ip net pointer",
+			vars: map[string]string{"V": "10.0.0.0/8"},
+			give: &mockTIPNetPtr{},
+			want: &mockTIPNetPtr{ipNet},
+		},
+		{
+			name:    "parse error ip net",
+			vars:    map[string]string{"V": "10.0.0.0"},
+			give:    &mockTIPNet{},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1206,6 +1319,162 @@ func TestBinder_TypeTests(t *testing.T) {
 	}
 }
 
+type mockExpandingSource struct {
+	mockSource
+}
+
+func (m mockExpandingSource) Expand(val string) (string, error) {
+	if val == "fail" {
+		return "", fmt.Errorf("cannot expand %q", val)
+	}
+	return strings.ToUpper(val), nil
+}
+
+var _ bind.Expander = (*mockExpandingSource)(nil)
+
+func TestBinder_Expander(t *testing.T) {
+	t.Parallel()
+
+	b := bind.New("bind")
+
+	t.Run("values and defaults", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct {
+			A string   `bind:"a"`
+			B []string `bind:"b"`
+			C string   `bind:"c,default:baz"`
+		}
+		src := mockExpandingSource{mockSource{
+			"a": {"foo"},
+			"b": {"x", "y"},
+		}}
+		if err := b.Bind(&cfg, "", src); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		if cfg.A != "FOO" || cfg.C != "BAZ" {
+			t.Errorf("got %q, %q; want %q, %q", cfg.A, cfg.C, "FOO", "BAZ")
+		}
+		if !reflect.DeepEqual(cfg.B, []string{"X", "Y"}) {
+			t.Errorf("got %v; want [X Y]", cfg.B)
+		}
+		// The source's own data must not be modified.
+		if got := src.mockSource["a"][0]; got != "foo" {
+			t.Errorf("source: got %q; want %q", got, "foo")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct {
+			A string `bind:"a"`
+		}
+		src := mockExpandingSource{mockSource{"a": {"fail"}}}
+		if err := b.Bind(&cfg, "", src); err == nil {
+			t.Fatal("should have returned an error")
+		}
+	})
+}
+
+func TestBinder_Map(t *testing.T) {
+	t.Parallel()
+
+	b := bind.New("bind")
+
+	t.Run("default delimiters", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct {
+			V map[string]bool `bind:"v"`
+		}
+		src := mockSource{"v": {"a:true,b:false"}}
+		if err := b.Bind(&cfg, "", src); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		want := map[string]bool{"a": true, "b": false}
+		if !reflect.DeepEqual(cfg.V, want) {
+			t.Errorf("got %v; want %v", cfg.V, want)
+		}
+	})
+
+	t.Run("custom delimiters", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct {
+			V map[string]int `bind:"v,pairs:';',delim:'='"`
+		}
+		src := mockSource{"v": {"a=1;b=2"}}
+		if err := b.Bind(&cfg, "", src); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		want := map[string]int{"a": 1, "b": 2}
+		if !reflect.DeepEqual(cfg.V, want) {
+			t.Errorf("got %v; want %v", cfg.V, want)
+		}
+	})
+
+	t.Run("value containing delimiter", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct {
+			V map[string]string `bind:"v"`
+		}
+		src := mockSource{"v": {"db:postgres://host:5432"}}
+		if err := b.Bind(&cfg, "", src); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		want := map[string]string{"db": "postgres://host:5432"}
+		if !reflect.DeepEqual(cfg.V, want) {
+			t.Errorf("got %v; want %v", cfg.V, want)
+		}
+	})
+
+	t.Run("multiple values", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct {
+			V map[int]string `bind:"v"`
+		}
+		src := mockSource{"v": {"1:a", "2:b"}}
+		if err := b.Bind(&cfg, "", src); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		want := map[int]string{1: "a", 2: "b"}
+		if !reflect.DeepEqual(cfg.V, want) {
+			t.Errorf("got %v; want %v", cfg.V, want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct {
+			V map[string]string `bind:"v"`
+		}
+		src := mockSource{"v": {""}}
+		if err := b.Bind(&cfg, "", src); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		if cfg.V == nil || len(cfg.V) != 0 {
+			t.Errorf("got %v; want an empty map", cfg.V)
+		}
+	})
+
+	errs := []struct {
+		name string
+		give string
+	}{
+		{"missing delimiter", "a"},
+		{"bad key", "x:1"},
+		{"bad value", "1:x"},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var cfg struct {
+				V map[int]int `bind:"v"`
+			}
+			if err := b.Bind(&cfg, "", mockSource{"v": {tt.give}}); err == nil {
+				t.Fatal("should have returned an error")
+			}
+		})
+	}
+}
+
 // An optional section reached through a pointer must stay nil unless the
 // source actually supplies something for it. Materializing it regardless
 // would make `cfg.Section != nil` a useless test.
@@ -1399,3 +1668,110 @@ func TestBinder_FailedSectionIsNotAttached(t *testing.T) {
 		t.Errorf("attached a section that failed to bind: %+v", cfg.TLS)
 	}
 }
+
+func TestBinder_Indexed(t *testing.T) {
+	t.Parallel()
+
+	type Upstream struct {
+		Host string `bind:"host"`
+		Port int    `bind:"port,default:80"`
+	}
+	type Config struct {
+		Upstreams []Upstream  `bind:"up"`
+		Backups   []*Upstream `bind:"bak"`
+	}
+
+	tests := []struct {
+		name string
+		src  mockSource
+		want []Upstream
+	}{
+		{"nothing", mockSource{}, nil},
+		{
+			"contiguous",
+			mockSource{
+				"up_0_host": {"a"},
+				"up_0_port": {"8080"},
+				"up_1_host": {"b"},
+			},
+			[]Upstream{{"a", 8080}, {"b", 80}},
+		},
+		{
+			"gap",
+			mockSource{"up_0_host": {"a"}, "up_2_host": {"c"}},
+			[]Upstream{{"a", 80}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var cfg Config
+			if err := bind.New("bind").Bind(&cfg, "", tt.src); err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+
+			if !reflect.DeepEqual(cfg.Upstreams, tt.want) {
+				t.Errorf("got %v; want %v", cfg.Upstreams, tt.want)
+			}
+			if cfg.Backups != nil {
+				t.Errorf("got %v; want nil", cfg.Backups)
+			}
+		})
+	}
+
+	t.Run("pointer elements", func(t *testing.T) {
+		t.Parallel()
+
+		src := mockSource{"bak_0_host": {"a"}, "bak_1_port": {"81"}}
+		var cfg Config
+		if err := bind.New("bind").Bind(&cfg, "", src); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+
+		if len(cfg.Backups) != 2 {
+			t.Fatalf("got %d elements; want 2", len(cfg.Backups))
+		}
+		if got := *cfg.Backups[0]; got != (Upstream{"a", 80}) {
+			t.Errorf("got %v; want %v", got, Upstream{"a", 80})
+		}
+		if got := *cfg.Backups[1]; got != (Upstream{"", 81}) {
+			t.Errorf("got %v; want %v", got, Upstream{"", 81})
+		}
+	})
+
+	t.Run("element error", func(t *testing.T) {
+		t.Parallel()
+
+		src := mockSource{"up_0_port": {"x"}}
+		var cfg Config
+		if err := bind.New("bind").Bind(&cfg, "", src); err == nil {
+			t.Fatal("should have returned an error")
+		}
+	})
+
+	t.Run("describe", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := bind.New("bind").Describe(reflect.TypeFor[Config](), "")
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+
+		want := []string{
+			"up_<n>_host Upstreams[].Host",
+			"up_<n>_port Upstreams[].Port",
+			"bak_<n>_host Backups[].Host",
+			"bak_<n>_port Backups[].Port",
+		}
+		if len(got) != len(want) {
+			t.Fatalf("got %d descriptors; want %d", len(got), len(want))
+		}
+		for i, d := range got {
+			if s := d.Key + " " + d.Field; s != want[i] {
+				t.Errorf("got %q; want %q", s, want[i])
+			}
+		}
+	})
+}
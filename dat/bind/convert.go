@@ -20,12 +20,14 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"net"
 	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/deep-rent/nexus/std/ascii"
 	"github.com/deep-rent/nexus/std/pointer"
 )
 
@@ -35,6 +37,9 @@ func setValues(rv reflect.Value, vals []string, f *Flags) error {
 	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
 		return setSlice(rv, vals, f)
 	}
+	if rv.Kind() == reflect.Map {
+		return setMap(rv, vals, f)
+	}
 
 	v := vals[0] // Primitive types only take the first value
 
@@ -47,6 +52,8 @@ func setValues(rv reflect.Value, vals []string, f *Flags) error {
 		return setLocation(rv, v)
 	case typeURL:
 		return setURL(rv, v)
+	case typeIPNet:
+		return setIPNet(rv, v)
 	}
 
 	if u, ok := asTextUnmarshaler(rv); ok {
@@ -64,8 +71,8 @@ func setOther(rv reflect.Value, v string, f *Flags) error {
 	case reflect.Slice:
 		return setBytes(rv, v, f)
 	case reflect.Bool:
-		b, err := strconv.ParseBool(v)
-		if err != nil {
+		b, ok := parseBool(v)
+		if !ok {
 			return fmt.Errorf("%q is not a bool", v)
 		}
 		rv.SetBool(b)
@@ -115,6 +122,22 @@ func setOther(rv reflect.Value, v string, f *Flags) error {
 	return nil
 }
 
+// parseBool extends [strconv.ParseBool] with the spellings "yes", "no", "y",
+// "n", "on", and "off", which are matched case-insensitively.
+func parseBool(v string) (bool, bool) {
+	if b, err := strconv.ParseBool(v); err == nil {
+		return b, true
+	}
+	switch ascii.ToLower(v) {
+	case "true", "yes", "y", "on":
+		return true, true
+	case "false", "no", "n", "off":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
 // setTime parses and sets a [time.Time] value based on the provided format and
 // unit options.
 func setTime(rv reflect.Value, v string, f *Flags) error {
@@ -211,6 +234,16 @@ func setURL(rv reflect.Value, v string) error {
 	return nil
 }
 
+// setIPNet parses and sets a [net.IPNet] value from CIDR notation.
+func setIPNet(rv reflect.Value, v string) error {
+	_, n, err := net.ParseCIDR(v)
+	if err != nil {
+		return fmt.Errorf("%q is not a CIDR network", v)
+	}
+	rv.Set(reflect.ValueOf(*n))
+	return nil
+}
+
 // setBytes parses and sets a []byte slice value, supporting special
 // encoding formats like hex, base32, and base64.
 func setBytes(rv reflect.Value, v string, f *Flags) error {
@@ -263,6 +296,50 @@ func setSlice(rv reflect.Value, vals []string, f *Flags) error {
 	return nil
 }
 
+// setMap parses and sets a map value from a list of key-value pairs. If
+// exactly one value is provided, it is split into entries using the pairs
+// delimiter, falling back to the split delimiter. Each entry is then split
+// into key and value at the first occurrence of the key-value delimiter.
+func setMap(rv reflect.Value, vals []string, f *Flags) error {
+	sep := f.Pairs
+	if sep == "" {
+		sep = f.Split
+	}
+	if len(vals) == 1 && sep != "" {
+		vals = strings.Split(vals[0], sep)
+	}
+
+	rt := rv.Type()
+	m := reflect.MakeMapWithSize(rt, len(vals))
+	if len(vals) == 1 && vals[0] == "" {
+		rv.Set(m)
+		return nil
+	}
+
+	for _, pair := range vals {
+		k, v, ok := strings.Cut(pair, f.Delim)
+		if !ok {
+			return fmt.Errorf(
+				"map entry %q lacks key-value delimiter %q", pair, f.Delim,
+			)
+		}
+		key := reflect.New(rt.Key()).Elem()
+		if err := setValues(key, []string{k}, f); err != nil {
+			return fmt.Errorf("failed to parse map key %q: %w", k, err)
+		}
+		val := reflect.New(rt.Elem()).Elem()
+		if err := setValues(val, []string{v}, f); err != nil {
+			return fmt.Errorf(
+				"failed to parse map value for key %q: %w", k, err,
+			)
+		}
+		m.SetMapIndex(key, val)
+	}
+
+	rv.Set(m)
+	return nil
+}
+
 // asTextUnmarshaler checks if the given [reflect.Value] implements the
 // [encoding.TextUnmarshaler] interface.
 func asTextUnmarshaler(rv reflect.Value) (encoding.TextUnmarshaler, bool) {
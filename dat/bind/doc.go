@@ -18,8 +18,8 @@
 // It serves as the core binding mechanism for configuration packages like env
 // and HTTP routing layers. It parses struct tags, supports configurable field
 // name transformations, handles primitive and standard library type conversions
-// (such as [time.Duration] and [url.URL]), and supports optional reflection
-// metadata caching for optimal performance.
+// (such as [time.Duration], [url.URL], and [net.IP]), and supports optional
+// reflection metadata caching for optimal performance.
 //
 // # Usage
 //
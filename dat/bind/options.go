@@ -17,6 +17,7 @@ package bind
 type config struct {
 	transform Transformer
 	cache     bool
+	strict    bool
 }
 
 // Option configures a Binder.
@@ -37,3 +38,11 @@ func WithCache(enable bool) Option {
 		c.cache = enable
 	}
 }
+
+// WithRequireAll makes every field required unless it has a default value or
+// is explicitly marked with the "optional" option.
+func WithRequireAll(enable bool) Option {
+	return func(c *config) {
+		c.strict = enable
+	}
+}
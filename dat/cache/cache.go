@@ -15,10 +15,15 @@
 package cache
 
 import (
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -41,11 +46,25 @@ import (
 // time, it should generally respect the context contained in the [Response].
 type Mapper[T any] func(r *Response) (T, error)
 
+// MapBody adapts a function that only needs the raw response body, such as
+// a plain decoder, to the [Mapper] type.
+func MapBody[T any](fn func(body []byte) (T, error)) Mapper[T] {
+	return func(r *Response) (T, error) {
+		return fn(r.Body)
+	}
+}
+
 // Response provides contextual information to a [Mapper] function, including
-// the response body, request context, and a logger.
+// the response body and metadata, request context, and a logger.
 type Response struct {
 	// Body is the raw response payload to be mapped.
 	Body []byte
+	// Header holds the response headers, which allows decisions based on the
+	// Content-Type, for example.
+	Header http.Header
+	// StatusCode is the HTTP status code of the response, which is 200 unless
+	// further codes were accepted through [WithAcceptStatus].
+	StatusCode int
 	// Ctx is the context controlling the HTTP exchange.
 	Ctx context.Context
 	// Logger is the logger instance inherited from the [Controller].
@@ -69,6 +88,33 @@ type Controller[T any] interface {
 	// consumers to block until the cache is warmed up. When the channel is
 	// closed, [Controller.Get] is guaranteed to report a value.
 	Ready() <-chan struct{}
+
+	// Refresh fetches the resource immediately, independent of the schedule,
+	// and updates the cache if it changed. This is useful when an external
+	// signal, such as a webhook, announces a change of the resource. It
+	// returns an error if the refresh failed, in which case the previously
+	// cached value is retained. It is safe to call concurrently with the
+	// scheduled runs; overlapping refreshes are carried out one at a time.
+	Refresh(ctx context.Context) error
+
+	// Stats reports the state of the most recent refresh cycles.
+	Stats() Stats
+}
+
+// Stats is a snapshot of the refresh state of a [Controller], intended for
+// observability.
+type Stats struct {
+	// LastSuccess is the time of the last successful refresh, including those
+	// answered with 304, or the zero time if there was none yet.
+	LastSuccess time.Time
+	// LastStatus is the HTTP status code of the last response received, or 0
+	// if no response was received yet.
+	LastStatus int
+	// ETag is the entity tag of the cached resource, or an empty string if
+	// the origin did not provide one.
+	ETag string
+	// Failures is the number of consecutive failed refreshes.
+	Failures int
 }
 
 // NewController creates and configures a new cache [Controller].
@@ -83,6 +129,23 @@ func NewController[T any](
 	url string,
 	mapper Mapper[T],
 	opts ...Option,
+) Controller[T] {
+	return NewTypedController(url, mapper, nil, opts...)
+}
+
+// NewTypedController is like [NewController], but additionally applies
+// options that depend on the resource type, such as [WithSeed] and
+// [WithOnUpdate]:
+//
+//	ctrl := cache.NewTypedController(url, mapper,
+//		[]cache.TypedOption[Config]{cache.WithSeed(fallback)},
+//		cache.WithMinInterval(time.Minute),
+//	)
+func NewTypedController[T any](
+	url string,
+	mapper Mapper[T],
+	typed []TypedOption[T],
+	opts ...Option,
 ) Controller[T] {
 	if url == "" {
 		panic("URL must not be empty")
@@ -97,6 +160,8 @@ func NewController[T any](
 		logger:      log.Discard(),
 		client:      transport.DefaultClient,
 		now:         clock.System,
+		decompress:  true,
+		limit:       DefaultDecompressLimit,
 	}
 	for _, opt := range opts {
 		opt(&cfg)
@@ -118,7 +183,7 @@ func NewController[T any](
 		)
 	}
 
-	return &controller[T]{
+	ctrl := &controller[T]{
 		url:         url,
 		mapper:      mapper,
 		client:      cfg.client,
@@ -128,9 +193,24 @@ func NewController[T any](
 		jitter:      jitter.New(cfg.jitter, nil),
 		logger:      cfg.logger,
 		now:         cfg.now,
+		stale:       cfg.stale,
+		request:     cfg.request,
+		accept:      cfg.accept,
+		decompress:  cfg.decompress,
+		limit:       cfg.limit,
 		stats:       newStats(cfg.registry, url),
 		readyChan:   make(chan struct{}),
 	}
+
+	var tc typedConfig[T]
+	for _, opt := range typed {
+		opt(&tc)
+	}
+	if tc.seeded {
+		ctrl.resource, ctrl.ok = tc.seed, true
+	}
+	ctrl.onUpdate = tc.onUpdate
+	return ctrl
 }
 
 // controller is the internal implementation of the [Controller] interface.
@@ -144,8 +224,17 @@ type controller[T any] struct {
 	jitter      *jitter.Jitter   // scatters the refresh interval
 	logger      *log.Logger      // destination for internal logs
 	now         clock.Clock      // clock used to interpret date headers
+	stale       bool             // honors stale-while-revalidate
 	stats       stats            // counts refresh cycles by outcome
+	onUpdate    func(T, T)       // notified after each update, if set
+	accept      []int            // status codes parsed besides 200
+	decompress  bool             // decodes gzip and deflate bodies
+	limit       int64            // maximum size of a decoded body
 
+	// request builds the outgoing request, or is nil for a plain GET.
+	request func(context.Context) (*http.Request, error)
+
+	cycle     sync.Mutex    // serializes refresh cycles
 	readyOnce sync.Once     // ensures the ready channel is closed only once
 	readyChan chan struct{} // closed upon the first successful fetch
 
@@ -155,6 +244,8 @@ type controller[T any] struct {
 	failures     int          // consecutive failed refreshes
 	etag         string       // ETag of the last successful response
 	lastModified string       // Last-Modified of the last successful response
+	lastSuccess  time.Time    // time of the last successful refresh
+	lastStatus   int          // status code of the last response received
 }
 
 // Get retrieves the currently cached resource.
@@ -164,6 +255,18 @@ func (c *controller[T]) Get() (T, bool) {
 	return c.resource, c.ok
 }
 
+// Stats returns a snapshot of the refresh state.
+func (c *controller[T]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return Stats{
+		LastSuccess: c.lastSuccess,
+		LastStatus:  c.lastStatus,
+		ETag:        c.etag,
+		Failures:    c.failures,
+	}
+}
+
 // Ready returns a channel that is closed when the cache is first populated.
 func (c *controller[T]) Ready() <-chan struct{} {
 	return c.readyChan
@@ -178,6 +281,22 @@ func (c *controller[T]) ready() {
 // [schedule.Tick] interface. It handles conditional requests, response
 // parsing, and caching, and returns the duration to wait before the next run.
 func (c *controller[T]) Run(ctx context.Context) time.Duration {
+	d, _ := c.sync(ctx)
+	return d
+}
+
+// Refresh performs a single fetch-and-cache cycle outside the schedule.
+func (c *controller[T]) Refresh(ctx context.Context) error {
+	_, err := c.sync(ctx)
+	return err
+}
+
+// sync executes a single fetch-and-cache cycle, returning the duration to
+// wait before the next run along with the reason the cycle failed, if it did.
+func (c *controller[T]) sync(ctx context.Context) (time.Duration, error) {
+	c.cycle.Lock()
+	defer c.cycle.Unlock()
+
 	c.logger.Debug(ctx, "Fetching resource")
 
 	res, err := c.fetch(ctx)
@@ -190,15 +309,19 @@ func (c *controller[T]) Run(ctx context.Context) time.Duration {
 				log.Error(err),
 			)
 		}
-		return c.retry(ctx)
+		return c.retry(ctx, err)
 	}
 	defer c.close(res)
 
-	switch code := res.StatusCode; code {
-	case http.StatusNotModified:
+	c.mu.Lock()
+	c.lastStatus = res.StatusCode
+	c.mu.Unlock()
+
+	switch code := res.StatusCode; {
+	case code == http.StatusNotModified:
 		return c.unchanged(ctx, res)
 
-	case http.StatusOK:
+	case code == http.StatusOK, slices.Contains(c.accept, code):
 		return c.update(ctx, res)
 
 	default:
@@ -206,13 +329,21 @@ func (c *controller[T]) Run(ctx context.Context) time.Duration {
 			"Received an unexpected HTTP status code",
 			log.Int("status", code),
 		)
-		return c.retry(ctx)
+		return c.retry(ctx, fmt.Errorf("unexpected status code %d", code))
 	}
 }
 
-// fetch issues a conditional GET for the resource.
+// fetch issues a conditional request for the resource.
 func (c *controller[T]) fetch(ctx context.Context) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	var (
+		req *http.Request
+		err error
+	)
+	if c.request != nil {
+		req, err = c.request(ctx)
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +367,7 @@ func (c *controller[T]) fetch(ctx context.Context) (*http.Response, error) {
 func (c *controller[T]) unchanged(
 	ctx context.Context,
 	res *http.Response,
-) time.Duration {
+) (time.Duration, error) {
 	c.mu.RLock()
 	etag, ok := c.etag, c.ok
 	c.mu.RUnlock()
@@ -250,7 +381,7 @@ func (c *controller[T]) unchanged(
 		c.mu.Lock()
 		c.etag, c.lastModified = "", ""
 		c.mu.Unlock()
-		return c.retry(ctx)
+		return c.retry(ctx, errors.New("resource unchanged but not cached"))
 	}
 
 	c.logger.Debug(ctx,
@@ -258,37 +389,40 @@ func (c *controller[T]) unchanged(
 		log.String("etag", etag),
 	)
 	c.stats.unchanged.Inc()
-	return c.refresh(res.Header)
+	return c.refresh(res.Header), nil
 }
 
 // update handles a 200 response, replacing the cached value.
 func (c *controller[T]) update(
 	ctx context.Context,
 	res *http.Response,
-) time.Duration {
-	body, err := io.ReadAll(res.Body)
+) (time.Duration, error) {
+	body, err := c.read(res)
 	if err != nil {
 		c.logger.Error(ctx,
 			"Failed to read response body",
 			log.Error(err),
 		)
-		return c.retry(ctx)
+		return c.retry(ctx, err)
 	}
 
 	resource, err := c.mapper(&Response{
-		Body:   body,
-		Ctx:    ctx,
-		Logger: c.logger,
+		Body:       body,
+		Header:     res.Header,
+		StatusCode: res.StatusCode,
+		Ctx:        ctx,
+		Logger:     c.logger,
 	})
 	if err != nil {
 		c.logger.Error(ctx,
 			"Couldn't parse response body",
 			log.Error(err),
 		)
-		return c.retry(ctx)
+		return c.retry(ctx, err)
 	}
 
 	c.mu.Lock()
+	old := c.resource
 	c.resource = resource
 	c.etag = header.ETag(res.Header)
 	c.lastModified = res.Header.Get("Last-Modified")
@@ -302,7 +436,55 @@ func (c *controller[T]) update(
 	// Signalled only once a value is actually available, so that consumers
 	// blocked on Ready are guaranteed a hit from Get.
 	c.ready()
-	return c.refresh(res.Header)
+
+	// Invoked without holding the lock, so the callback may call Get.
+	if c.onUpdate != nil {
+		c.onUpdate(old, resource)
+	}
+	return c.refresh(res.Header), nil
+}
+
+// read consumes the response body, decoding it first if it is compressed
+// and decompression is enabled.
+func (c *controller[T]) read(res *http.Response) ([]byte, error) {
+	if !c.decompress {
+		return io.ReadAll(res.Body)
+	}
+
+	var r io.ReadCloser
+	switch enc := strings.ToLower(
+		strings.TrimSpace(res.Header.Get("Content-Encoding")),
+	); enc {
+	case "gzip", "x-gzip":
+		zr, err := gzip.NewReader(res.Body)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s body: %w", enc, err)
+		}
+		r = zr
+	case "deflate":
+		zr, err := zlib.NewReader(res.Body)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s body: %w", enc, err)
+		}
+		r = zr
+	default:
+		return io.ReadAll(res.Body)
+	}
+	defer r.Close()
+
+	// One byte beyond the limit tells an oversized body from one that fits.
+	body, err := io.ReadAll(io.LimitReader(r, c.limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > c.limit {
+		return nil, fmt.Errorf("decoded body exceeds %d bytes", c.limit)
+	}
+	// The headers now describe the decoded body, just as if the transport
+	// had decompressed it.
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	return body, nil
 }
 
 // close releases the response body.
@@ -316,23 +498,30 @@ func (c *controller[T]) close(res *http.Response) {
 }
 
 // refresh calculates the duration until the next fetch based on caching
-// headers, clamped by the configured min/max intervals and optionally
-// scattered by jitter.
+// headers, extended by the stale-while-revalidate window if enabled, clamped
+// by the configured min/max intervals and optionally scattered by jitter.
 func (c *controller[T]) refresh(h http.Header) time.Duration {
 	c.mu.Lock()
 	c.failures = 0
+	c.lastSuccess = c.now()
 	c.mu.Unlock()
 
 	d := header.Lifetime(h, c.now)
+	if c.stale && d > 0 {
+		d += header.StaleWhileRevalidate(h)
+	}
 	d = min(max(d, c.minInterval), c.maxInterval)
 	return c.jitter.Apply(d)
 }
 
 // retry records a failed refresh and returns the delay before the next
-// attempt, which grows with the number of consecutive failures. It is the
-// single sink for every failure path, so it also counts the cycle as an
-// error.
-func (c *controller[T]) retry(ctx context.Context) time.Duration {
+// attempt, which grows with the number of consecutive failures, along with
+// the given cause. It is the single sink for every failure path, so it also
+// counts the cycle as an error.
+func (c *controller[T]) retry(
+	ctx context.Context,
+	cause error,
+) (time.Duration, error) {
 	c.stats.failed.Inc()
 
 	c.mu.Lock()
@@ -346,7 +535,7 @@ func (c *controller[T]) retry(ctx context.Context) time.Duration {
 		log.Int("failures", n),
 		log.Duration("delay", d),
 	)
-	return d
+	return d, cause
 }
 
 var _ Controller[any] = (*controller[any])(nil)
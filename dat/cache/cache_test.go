@@ -15,10 +15,17 @@
 package cache_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -225,6 +232,48 @@ func TestController_Run_ClampsInterval(t *testing.T) {
 	}
 }
 
+func TestController_Run_StaleWhileRevalidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header string
+		enable bool
+		want   time.Duration
+	}{
+		{"disabled", "max-age=600, stale-while-revalidate=600", false,
+			10 * time.Minute},
+		{"enabled", "max-age=600, stale-while-revalidate=600", true,
+			20 * time.Minute},
+		{"clamped", "max-age=600, stale-while-revalidate=86400", true,
+			time.Hour},
+		{"not cacheable", "no-store, stale-while-revalidate=600", true,
+			time.Minute},
+		{"shared", "max-age=60, s-maxage=600", false, 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, _ := serve(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Cache-Control", tt.header)
+				_, _ = w.Write([]byte("payload"))
+			})
+
+			ctrl := cache.NewController(srv.URL, text,
+				cache.WithMinInterval(time.Minute),
+				cache.WithMaxInterval(time.Hour),
+				cache.WithStaleWhileRevalidate(tt.enable),
+			)
+
+			if got := ctrl.Run(t.Context()); got != tt.want {
+				t.Errorf("interval: got %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestController_Run_ConditionalHeaders(t *testing.T) {
 	t.Parallel()
 
@@ -541,6 +590,517 @@ func TestController_ConcurrentAccess(t *testing.T) {
 	}
 }
 
+func TestController_Refresh(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		status = http.StatusOK
+		body   = "v1"
+	)
+	srv, h := serve(t, func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	})
+
+	ctrl := cache.NewController(srv.URL, text,
+		cache.WithBackoff(backoff.Constant(0)),
+	)
+
+	if err := ctrl.Refresh(t.Context()); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if got, _ := ctrl.Get(); got != "v1" {
+		t.Errorf("resource: got %q; want %q", got, "v1")
+	}
+
+	mu.Lock()
+	body = "v2"
+	mu.Unlock()
+
+	if err := ctrl.Refresh(t.Context()); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if got, _ := ctrl.Get(); got != "v2" {
+		t.Errorf("resource: got %q; want %q", got, "v2")
+	}
+
+	mu.Lock()
+	status = http.StatusInternalServerError
+	mu.Unlock()
+
+	if err := ctrl.Refresh(t.Context()); err == nil {
+		t.Error("should have returned an error")
+	}
+	if got, _ := ctrl.Get(); got != "v2" {
+		t.Errorf("resource: got %q; want %q", got, "v2")
+	}
+
+	if n := h.count(); n != 3 {
+		t.Errorf("requests: got %d; want 3", n)
+	}
+}
+
+// Refresh and Run must be safe to call concurrently.
+func TestController_RefreshConcurrentWithRun(t *testing.T) {
+	t.Parallel()
+
+	srv, h := serve(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("payload"))
+	})
+
+	ctrl := cache.NewController(srv.URL, text)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for range 10 {
+				ctrl.Run(t.Context())
+			}
+		})
+		wg.Go(func() {
+			for range 10 {
+				if err := ctrl.Refresh(t.Context()); err != nil {
+					t.Errorf("should not have returned an error: %v", err)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if n := h.count(); n != 80 {
+		t.Errorf("requests: got %d; want 80", n)
+	}
+}
+
+func TestController_Stats(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		status = http.StatusOK
+	)
+	srv, _ := serve(t, func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("payload"))
+	})
+
+	now := time.Date(2026, time.July, 20, 12, 0, 0, 0, time.UTC)
+	ctrl := cache.NewController(srv.URL, text,
+		cache.WithBackoff(backoff.Constant(0)),
+		cache.WithClock(clock.Frozen(now)),
+	)
+
+	if got := ctrl.Stats(); got != (cache.Stats{}) {
+		t.Errorf("got %+v; want zero stats", got)
+	}
+
+	ctrl.Run(t.Context())
+
+	want := cache.Stats{
+		LastSuccess: now,
+		LastStatus:  http.StatusOK,
+		ETag:        `"v1"`,
+	}
+	if got := ctrl.Stats(); got != want {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+
+	mu.Lock()
+	status = http.StatusBadGateway
+	mu.Unlock()
+
+	ctrl.Run(t.Context())
+	ctrl.Run(t.Context())
+
+	want.LastStatus = http.StatusBadGateway
+	want.Failures = 2
+	if got := ctrl.Stats(); got != want {
+		t.Errorf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestController_Seed(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := serve(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("payload"))
+	})
+
+	ctrl := cache.NewTypedController(srv.URL, text,
+		[]cache.TypedOption[string]{cache.WithSeed("seed")},
+	)
+
+	if got, ok := ctrl.Get(); !ok || got != "seed" {
+		t.Errorf("got (%q, %t); want (%q, true)", got, ok, "seed")
+	}
+
+	select {
+	case <-ctrl.Ready():
+		t.Error("ready channel should still be open")
+	default:
+	}
+
+	ctrl.Run(t.Context())
+
+	if got, ok := ctrl.Get(); !ok || got != "payload" {
+		t.Errorf("got (%q, %t); want (%q, true)", got, ok, "payload")
+	}
+}
+
+func TestController_OnUpdate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu   sync.Mutex
+		body = "v1"
+	)
+	srv, _ := serve(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("If-None-Match") == `"`+body+`"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"`+body+`"`)
+		_, _ = w.Write([]byte(body))
+	})
+
+	type update struct{ prev, next, got string }
+	var (
+		updates []update
+		ctrl    cache.Controller[string]
+	)
+	ctrl = cache.NewTypedController(srv.URL, text,
+		[]cache.TypedOption[string]{
+			cache.WithSeed("seed"),
+			cache.WithOnUpdate(func(prev, next string) {
+				// Calling back into the controller must not deadlock.
+				got, _ := ctrl.Get()
+				updates = append(updates, update{prev, next, got})
+			}),
+		},
+	)
+
+	ctrl.Run(t.Context()) // 200
+	ctrl.Run(t.Context()) // 304
+
+	mu.Lock()
+	body = "v2"
+	mu.Unlock()
+
+	ctrl.Run(t.Context()) // 200
+
+	want := []update{{"seed", "v1", "v1"}, {"v1", "v2", "v2"}}
+	if !slices.Equal(updates, want) {
+		t.Errorf("got %v; want %v", updates, want)
+	}
+}
+
+func TestController_WithRequest(t *testing.T) {
+	t.Parallel()
+
+	srv, h := serve(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(body)
+	})
+
+	ctrl := cache.NewController(srv.URL, text,
+		cache.WithRequest(func(ctx context.Context) (*http.Request, error) {
+			return http.NewRequestWithContext(ctx,
+				http.MethodPost, srv.URL, strings.NewReader("{ query }"),
+			)
+		}),
+	)
+
+	ctrl.Run(t.Context())
+	ctrl.Run(t.Context())
+
+	if got, _ := ctrl.Get(); got != "{ query }" {
+		t.Errorf("resource: got %q; want %q", got, "{ query }")
+	}
+
+	if got := h.header(2, "If-None-Match"); got != `"v1"` {
+		t.Errorf("If-None-Match: got %q; want %q", got, `"v1"`)
+	}
+
+	h.mu.Lock()
+	method := h.requests[0].Method
+	h.mu.Unlock()
+	if method != http.MethodPost {
+		t.Errorf("method: got %q; want %q", method, http.MethodPost)
+	}
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := cache.NewController(srv.URL, text,
+			cache.WithRequest(func(context.Context) (*http.Request, error) {
+				return nil, errors.New("boom")
+			}),
+		)
+
+		if err := ctrl.Refresh(t.Context()); err == nil {
+			t.Error("should have returned an error")
+		}
+	})
+}
+
+func TestController_WithAcceptStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		accept []int
+		want   bool
+	}{
+		{"not accepted", http.StatusPartialContent, nil, false},
+		{
+			"accepted",
+			http.StatusPartialContent,
+			[]int{http.StatusPartialContent},
+			true,
+		},
+		{
+			"one of many",
+			http.StatusNonAuthoritativeInfo,
+			[]int{http.StatusPartialContent, http.StatusNonAuthoritativeInfo},
+			true,
+		},
+		{"ok always", http.StatusOK, []int{http.StatusPartialContent}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, _ := serve(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte("payload"))
+			})
+
+			ctrl := cache.NewController(srv.URL, text,
+				cache.WithAcceptStatus(tt.accept...),
+			)
+
+			err := ctrl.Refresh(t.Context())
+			if got := err == nil; got != tt.want {
+				t.Errorf("succeeded: got %t; want %t", got, tt.want)
+			}
+			if _, ok := ctrl.Get(); ok != tt.want {
+				t.Errorf("cached: got %t; want %t", ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestController_Response(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := serve(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusNonAuthoritativeInfo)
+		_, _ = w.Write([]byte("payload"))
+	})
+
+	var got *cache.Response
+	ctrl := cache.NewController(srv.URL,
+		func(r *cache.Response) (string, error) {
+			got = r
+			return string(r.Body), nil
+		},
+		cache.WithAcceptStatus(http.StatusNonAuthoritativeInfo),
+	)
+
+	if err := ctrl.Refresh(t.Context()); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	if got.StatusCode != http.StatusNonAuthoritativeInfo {
+		t.Errorf("status: got %d; want %d",
+			got.StatusCode, http.StatusNonAuthoritativeInfo)
+	}
+	if ct := got.Header.Get("Content-Type"); ct != "text/plain" {
+		t.Errorf("content type: got %q; want %q", ct, "text/plain")
+	}
+}
+
+func TestMapBody(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := serve(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("42"))
+	})
+
+	ctrl := cache.NewController(srv.URL, cache.MapBody(func(b []byte) (int, error) {
+		return strconv.Atoi(string(b))
+	}))
+
+	if err := ctrl.Refresh(t.Context()); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if got, _ := ctrl.Get(); got != 42 {
+		t.Errorf("got %d; want 42", got)
+	}
+}
+
+func TestController_Decompression(t *testing.T) {
+	t.Parallel()
+
+	compress := map[string]func(w io.Writer) io.WriteCloser{
+		"gzip": func(w io.Writer) io.WriteCloser {
+			return gzip.NewWriter(w)
+		},
+		"deflate": func(w io.Writer) io.WriteCloser {
+			return zlib.NewWriter(w)
+		},
+	}
+
+	tests := []struct {
+		name     string
+		encoding string
+		enable   bool
+		want     string
+	}{
+		{"gzip", "gzip", true, "payload"},
+		{"deflate", "deflate", true, "payload"},
+		{"identity", "", true, "payload"},
+		{"disabled", "gzip", false, "\x1f\x8b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv, _ := serve(t, func(w http.ResponseWriter, _ *http.Request) {
+				if tt.encoding == "" {
+					_, _ = w.Write([]byte("payload"))
+					return
+				}
+				w.Header().Set("Content-Encoding", tt.encoding)
+				zw := compress[tt.encoding](w)
+				_, _ = zw.Write([]byte("payload"))
+				_ = zw.Close()
+			})
+
+			// An explicit Accept-Encoding keeps the transport from decoding
+			// the body on its own.
+			request := func(ctx context.Context) (*http.Request, error) {
+				req, err := http.NewRequestWithContext(
+					ctx, http.MethodGet, srv.URL, nil,
+				)
+				if err == nil {
+					req.Header.Set("Accept-Encoding", "gzip, deflate")
+				}
+				return req, err
+			}
+
+			var enc string
+			ctrl := cache.NewController(srv.URL,
+				func(r *cache.Response) (string, error) {
+					enc = r.Header.Get("Content-Encoding")
+					return string(r.Body), nil
+				},
+				cache.WithRequest(request),
+				cache.WithDecompression(tt.enable),
+			)
+
+			if err := ctrl.Refresh(t.Context()); err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+
+			got, _ := ctrl.Get()
+			if !strings.HasPrefix(got, tt.want) {
+				t.Errorf("body: got %q; want prefix %q", got, tt.want)
+			}
+			if tt.enable && enc != "" {
+				t.Errorf("content encoding: got %q; want none", enc)
+			}
+		})
+	}
+}
+
+func TestController_DecompressLimit(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := serve(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		_, _ = zw.Write(bytes.Repeat([]byte("a"), 64<<10))
+		_ = zw.Close()
+	})
+
+	request := func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(
+			ctx, http.MethodGet, srv.URL, nil,
+		)
+		if err == nil {
+			req.Header.Set("Accept-Encoding", "gzip")
+		}
+		return req, err
+	}
+
+	tests := []struct {
+		name  string
+		limit int64
+		ok    bool
+	}{
+		{"within limit", 64 << 10, true},
+		{"beyond limit", 1 << 10, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := cache.NewController(srv.URL, text,
+				cache.WithRequest(request),
+				cache.WithDecompressLimit(tt.limit),
+			)
+
+			err := ctrl.Refresh(t.Context())
+			if tt.ok && err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("should have returned an error")
+			}
+			if _, ok := ctrl.Get(); ok != tt.ok {
+				t.Errorf("cached: got %t; want %t", ok, tt.ok)
+			}
+		})
+	}
+}
+
+func TestController_Decompression_Corrupt(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := serve(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Encoding", "deflate")
+		_, _ = w.Write([]byte("not compressed"))
+	})
+
+	ctrl := cache.NewController(srv.URL, text)
+
+	if err := ctrl.Refresh(t.Context()); err == nil {
+		t.Error("should have returned an error")
+	}
+	if _, ok := ctrl.Get(); ok {
+		t.Error("should not have cached a value")
+	}
+}
+
 func TestController_Options(t *testing.T) {
 	t.Parallel()
 
@@ -22,15 +22,20 @@
 //
 // The interval is derived from the resource's caching headers (Cache-Control,
 // Expires) and clamped to the range configured via [WithMinInterval] and
-// [WithMaxInterval]. Failed refreshes do not fall back to that interval;
-// instead they back off exponentially from [DefaultRetryDelay], so a transient
-// outage is recovered from in seconds rather than after a full refresh cycle.
-// See [WithBackoff].
+// [WithMaxInterval]. A stale-while-revalidate window can be added on top of
+// the lifetime via [WithStaleWhileRevalidate]. Failed refreshes do not fall
+// back to that interval; instead they back off exponentially from
+// [DefaultRetryDelay], so a transient outage is recovered from in seconds
+// rather than after a full refresh cycle. See [WithBackoff].
 //
 // Conditional requests using ETag and Last-Modified reduce bandwidth: a
 // resource that has not changed is answered with 304 and the cached value is
 // retained.
 //
+// A refresh can also be triggered outside the schedule with
+// [Controller.Refresh], for example in response to a webhook announcing that
+// the resource changed.
+//
 // # Usage
 //
 // A typical use case involves creating a [schedule.Scheduler], defining a
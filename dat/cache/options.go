@@ -15,6 +15,7 @@
 package cache
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -33,6 +34,9 @@ const (
 	// failed refresh. Subsequent failures back off exponentially, up to the
 	// configured minimum interval.
 	DefaultRetryDelay = 5 * time.Second
+	// DefaultDecompressLimit is the default maximum size of a decoded
+	// response body.
+	DefaultDecompressLimit = 10 << 20 // 10 MiB
 )
 
 // config holds the internal configuration for the cache controller.
@@ -44,19 +48,41 @@ type config struct {
 	logger      *log.Logger      // destination for internal logs
 	client      *http.Client     // HTTP client used for fetching
 	now         clock.Clock      // clock used to interpret date headers
+	stale       bool             // whether to honor stale-while-revalidate
+	accept      []int            // status codes parsed besides 200
+	decompress  bool             // decodes gzip and deflate bodies
+	limit       int64            // maximum size of a decoded body
 
 	registry *metrics.Registry // records the refresh counter
+
+	// request builds the outgoing request, or is nil for a plain GET.
+	request func(context.Context) (*http.Request, error)
 }
 
 // Option is a function that configures the cache [Controller].
 type Option func(*config)
 
+// typedConfig holds the configuration that depends on the resource type.
+type typedConfig[T any] struct {
+	seed     T                  // value served before the first fetch
+	seeded   bool               // whether a seed was provided
+	onUpdate func(prev, next T) // callback invoked after each update
+}
+
+// TypedOption is a function that configures the parts of a cache
+// [Controller] that depend on the resource type T. Such options are passed to
+// [NewTypedController], so that a mismatch with the [Mapper] is caught at
+// compile time.
+type TypedOption[T any] func(*typedConfig[T])
+
 // WithClient sets the [http.Client] used to fetch the resource. Defaults to
 // [transport.DefaultClient]. Nil values are ignored.
 //
 // The controller reads the response body in full, so the client is
 // responsible for bounding its size. [transport.DefaultClient] does this;
-// a client assembled elsewhere may not.
+// a client assembled elsewhere may not. Such a bound only counts the bytes
+// on the wire, so bodies the controller decodes itself are additionally
+// capped by [WithDecompressLimit].
 func WithClient(client *http.Client) Option {
 	return func(c *config) {
 		if client != nil {
@@ -65,6 +91,61 @@ func WithClient(client *http.Client) Option {
 	}
 }
 
+// WithRequest customizes the request issued to fetch the resource, for
+// instance to POST a GraphQL query rather than GET the URL. The function is
+// called once per refresh, so any request body must be created anew each
+// time. The controller still adds the conditional headers (If-None-Match,
+// If-Modified-Since) to the returned request and handles the response as
+// usual. The URL passed to [NewController] then only serves to identify the
+// resource in logs and metrics. A nil value is ignored.
+func WithRequest(
+	fn func(ctx context.Context) (*http.Request, error),
+) Option {
+	return func(c *config) {
+		if fn != nil {
+			c.request = fn
+		}
+	}
+}
+
+// WithAcceptStatus marks additional status codes, such as 203 or 206, as
+// successful, so that their responses are parsed and cached like a 200.
+// Any other status besides 304 counts as a failed refresh and is retried
+// with backoff at the next tick. Codes worth retrying within the same cycle,
+// such as 503, are better handled by the client's transport; see
+// [retry.NewTransport].
+func WithAcceptStatus(codes ...int) Option {
+	return func(c *config) {
+		c.accept = append(c.accept, codes...)
+	}
+}
+
+// WithDecompression controls whether a response body that carries a gzip or
+// deflate Content-Encoding is decoded before it is passed to the [Mapper].
+// The header is removed from [Response.Header] once the body is decoded. Any
+// other encoding is passed through untouched. This covers origins that
+// compress their payload even though the transport did not negotiate it,
+// such as when a custom Accept-Encoding header is sent. Decoding is enabled
+// by default; disable it if the mapper needs the raw bytes.
+func WithDecompression(enable bool) Option {
+	return func(c *config) {
+		c.decompress = enable
+	}
+}
+
+// WithDecompressLimit sets the maximum size of a decoded response body in
+// bytes. A few kilobytes of compressed input can expand to gigabytes, so a
+// refresh whose body decodes to more than n bytes fails instead. If not
+// provided, [DefaultDecompressLimit] is used. Values of 0 or less are
+// ignored.
+func WithDecompressLimit(n int64) Option {
+	return func(c *config) {
+		if n > 0 {
+			c.limit = n
+		}
+	}
+}
+
 // WithMinInterval sets the minimum duration between successful refreshes. The
 // refresh delay, typically determined by caching headers, will not be shorter
 // than this. It also serves as the ceiling for the retry backoff, so that a
@@ -123,6 +204,44 @@ func WithBackoff(strategy backoff.Strategy) Option {
 	}
 }
 
+// WithStaleWhileRevalidate extends the refresh interval by the window granted
+// through the stale-while-revalidate directive of a response, if any. Since
+// the controller keeps serving the last known value while it refreshes, a
+// resource may be reused for as long as the origin permits it to be stale.
+// The extended interval is still clamped by the configured minimum and
+// maximum. By default, only the lifetime of the response is considered.
+func WithStaleWhileRevalidate(enable bool) Option {
+	return func(c *config) {
+		c.stale = enable
+	}
+}
+
+// WithSeed provides a value that [Controller.Get] reports, as if it had been
+// cached, until the first refresh succeeds. This spares callers from handling
+// an empty cache during startup, for instance when an empty key set would
+// reject every request. The seed does not close the [Controller.Ready]
+// channel, which still signals the first actual fetch.
+func WithSeed[T any](value T) TypedOption[T] {
+	return func(c *typedConfig[T]) {
+		c.seed, c.seeded = value, true
+	}
+}
+
+// WithOnUpdate registers a callback that is invoked after every refresh that
+// replaced the cached resource, receiving the previous and the new value. If
+// nothing was cached before, prev is the seed (see [WithSeed]) or the zero
+// value. Refreshes answered with 304 Not Modified do not trigger it. The
+// callback runs synchronously on the refreshing goroutine, but outside of any
+// lock, so it may safely call back into the controller. A nil value is
+// ignored.
+func WithOnUpdate[T any](fn func(prev, next T)) TypedOption[T] {
+	return func(c *typedConfig[T]) {
+		if fn != nil {
+			c.onUpdate = fn
+		}
+	}
+}
+
 // WithLogger provides a custom [log.Logger] for the controller. If not
 // provided, logging is disabled. A nil value is ignored.
 func WithLogger(logger *log.Logger) Option {
@@ -0,0 +1,175 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package header
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Flags for the valueless directives of a [CacheControl].
+const (
+	ccPublic uint8 = 1 << iota
+	ccPrivate
+	ccNoStore
+	ccNoCache
+	ccMustRevalidate
+	ccImmutable
+)
+
+// Flags marking the durations of a [CacheControl] that are set.
+const (
+	ccMaxAge uint8 = 1 << iota
+	ccSMaxAge
+	ccStaleWhileRevalidate
+	ccStaleIfError
+)
+
+// CacheControl builds the value of a Cache-Control response header. It is the
+// counterpart of [Directives], which parses one. The zero value holds no
+// directives; each method returns a copy with one more set, so that a policy
+// can be assembled in a single expression and shared safely:
+//
+//	cc := header.CacheControl{}.Public().MaxAge(time.Hour)
+//	w.Header().Set("Cache-Control", cc.String()) // "public, max-age=3600"
+//
+// Directives are rendered in a fixed order regardless of the order in which
+// they were set. Durations are truncated to whole seconds, and negative ones
+// are treated as zero. Setting a directive twice keeps the last value.
+type CacheControl struct {
+	flags   uint8         // valueless directives that are set
+	maxAge  time.Duration // max-age, if set
+	sMaxAge time.Duration // s-maxage, if set
+	swr     time.Duration // stale-while-revalidate, if set
+	sie     time.Duration // stale-if-error, if set
+	set     uint8         // durations above that are set
+}
+
+// Public adds the public directive, which allows shared caches to store the
+// response even if it would otherwise not be cacheable. It removes a
+// previously set private directive.
+func (c CacheControl) Public() CacheControl {
+	c.flags = c.flags&^ccPrivate | ccPublic
+	return c
+}
+
+// Private adds the private directive, which restricts storage to the cache of
+// the client. It removes a previously set public directive.
+func (c CacheControl) Private() CacheControl {
+	c.flags = c.flags&^ccPublic | ccPrivate
+	return c
+}
+
+// NoStore adds the no-store directive, which forbids caching the response.
+func (c CacheControl) NoStore() CacheControl {
+	c.flags |= ccNoStore
+	return c
+}
+
+// NoCache adds the no-cache directive, which requires caches to revalidate the
+// response with the origin before each reuse.
+func (c CacheControl) NoCache() CacheControl {
+	c.flags |= ccNoCache
+	return c
+}
+
+// MustRevalidate adds the must-revalidate directive, which forbids serving the
+// response once it is stale without revalidating it first.
+func (c CacheControl) MustRevalidate() CacheControl {
+	c.flags |= ccMustRevalidate
+	return c
+}
+
+// Immutable adds the immutable directive, which signals that the response will
+// not change while it is fresh.
+func (c CacheControl) Immutable() CacheControl {
+	c.flags |= ccImmutable
+	return c
+}
+
+// MaxAge adds the max-age directive, which sets the time for which the
+// response stays fresh.
+func (c CacheControl) MaxAge(d time.Duration) CacheControl {
+	c.maxAge, c.set = d, c.set|ccMaxAge
+	return c
+}
+
+// SMaxAge adds the s-maxage directive, which overrides max-age for shared
+// caches.
+func (c CacheControl) SMaxAge(d time.Duration) CacheControl {
+	c.sMaxAge, c.set = d, c.set|ccSMaxAge
+	return c
+}
+
+// StaleWhileRevalidate adds the stale-while-revalidate directive, which allows
+// caches to serve the stale response for the given window while they refresh
+// it in the background. See also [StaleWhileRevalidate].
+func (c CacheControl) StaleWhileRevalidate(d time.Duration) CacheControl {
+	c.swr, c.set = d, c.set|ccStaleWhileRevalidate
+	return c
+}
+
+// StaleIfError adds the stale-if-error directive, which allows caches to serve
+// the stale response for the given window if refreshing it fails.
+func (c CacheControl) StaleIfError(d time.Duration) CacheControl {
+	c.sie, c.set = d, c.set|ccStaleIfError
+	return c
+}
+
+// String renders the directives as a Cache-Control header value. It returns
+// an empty string if no directive is set.
+func (c CacheControl) String() string {
+	var b strings.Builder
+
+	flag := func(f uint8, name string) {
+		if c.flags&f != 0 {
+			add(&b, name)
+		}
+	}
+	delta := func(f uint8, name string, d time.Duration) {
+		if c.set&f != 0 {
+			add(&b, name)
+			b.WriteByte('=')
+			b.WriteString(strconv.FormatInt(int64(max(0, d)/time.Second), 10))
+		}
+	}
+
+	flag(ccPublic, "public")
+	flag(ccPrivate, "private")
+	flag(ccNoStore, "no-store")
+	flag(ccNoCache, "no-cache")
+	delta(ccMaxAge, "max-age", c.maxAge)
+	delta(ccSMaxAge, "s-maxage", c.sMaxAge)
+	flag(ccMustRevalidate, "must-revalidate")
+	flag(ccImmutable, "immutable")
+	delta(ccStaleWhileRevalidate, "stale-while-revalidate", c.swr)
+	delta(ccStaleIfError, "stale-if-error", c.sie)
+
+	return b.String()
+}
+
+// Header returns the directives as a Cache-Control [Header].
+func (c CacheControl) Header() Header {
+	return Header{Key: "Cache-Control", Value: c.String()}
+}
+
+// add appends a directive to a comma-separated list.
+func add(b *strings.Builder, name string) {
+	if b.Len() > 0 {
+		b.WriteString(", ")
+	}
+	b.WriteString(name)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package header_test
+
+import (
+	"maps"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/deep-rent/nexus/net/header"
+)
+
+func TestCacheControl(t *testing.T) {
+	t.Parallel()
+
+	var cc header.CacheControl
+
+	tests := []struct {
+		name string
+		cc   header.CacheControl
+		want string
+	}{
+		{"empty", cc, ""},
+		{"no store", cc.NoStore(), "no-store"},
+		{"public", cc.Public().MaxAge(time.Hour), "public, max-age=3600"},
+		{"private", cc.Private().NoCache(), "private, no-cache"},
+		{"public overrides private", cc.Private().Public(), "public"},
+		{"private overrides public", cc.Public().Private(), "private"},
+		{
+			"fixed order",
+			cc.StaleWhileRevalidate(time.Minute).
+				SMaxAge(10 * time.Minute).
+				MustRevalidate().
+				MaxAge(time.Minute).
+				Public(),
+			"public, max-age=60, s-maxage=600, must-revalidate, " +
+				"stale-while-revalidate=60",
+		},
+		{
+			"immutable",
+			cc.Public().MaxAge(365 * 24 * time.Hour).Immutable(),
+			"public, max-age=31536000, immutable",
+		},
+		{"stale if error", cc.StaleIfError(time.Hour), "stale-if-error=3600"},
+		{"zero max age", cc.MaxAge(0), "max-age=0"},
+		{"negative max age", cc.MaxAge(-time.Hour), "max-age=0"},
+		{"truncated", cc.MaxAge(1500 * time.Millisecond), "max-age=1"},
+		{"last wins", cc.MaxAge(time.Hour).MaxAge(time.Second), "max-age=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.cc.String(); got != tt.want {
+				t.Errorf("got %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheControl_Header(t *testing.T) {
+	t.Parallel()
+
+	h := header.CacheControl{}.Private().MaxAge(time.Minute).Header()
+	want := "Cache-Control: private, max-age=60"
+	if got := h.String(); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
+
+// The rendered value must read back the same through the parsing helpers.
+func TestCacheControl_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cc := header.CacheControl{}.
+		Public().
+		MaxAge(time.Hour).
+		StaleWhileRevalidate(time.Minute)
+
+	got := maps.Collect(header.Directives(cc.String()))
+	want := map[string]string{
+		"public":                 "",
+		"max-age":                "3600",
+		"stale-while-revalidate": "60",
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("directives: got %v; want %v", got, want)
+	}
+
+	h := http.Header{"Cache-Control": {cc.String()}}
+	if got := header.StaleWhileRevalidate(h); got != time.Minute {
+		t.Errorf("stale-while-revalidate: got %v; want %v", got, time.Minute)
+	}
+}
@@ -21,7 +21,8 @@
 //   - Parsing RFC 5988 Link headers to extract relations for API pagination.
 //   - Extracting credentials from an Authorization header.
 //   - Calculating cache lifetime from Cache-Control and Expires headers.
-//   - Determining throttle delays from Retry-After and X-Ratelimit-* headers.
+//   - Determining throttle delays from Retry-After and rate-limit headers.
+//   - Parsing and formatting Content-Disposition headers.
 //
 // It also provides a convenient [http.RoundTripper] implementation for
 // automatically attaching a static set of headers to all outgoing requests.
@@ -69,7 +69,8 @@ func Quote(tag string) string {
 //	}
 //
 // An empty header never matches, so a request that carries no validator is
-// always answered in full.
+// always answered in full. See [MatchStrongETag] for the strong comparison
+// used by If-Match.
 func MatchETag(value, tag string) bool {
 	value = strings.TrimSpace(value)
 	if value == "" || tag == "" {
@@ -88,6 +89,43 @@ func MatchETag(value, tag string) bool {
 	return false
 }
 
+// MatchStrongETag reports whether an If-Match header value matches the given
+// entity tag.
+//
+// It applies the strong comparison prescribed for If-Match by RFC 9110,
+// section 13.1.1: two tags only match if neither is weak and both are
+// identical, while "*" matches any current representation. This suits
+// optimistic concurrency control, where a write must only proceed if the
+// client saw the exact current state:
+//
+//	if !header.MatchStrongETag(r.Header.Get("If-Match"), tag) {
+//		w.WriteHeader(http.StatusPreconditionFailed)
+//		return
+//	}
+//
+// An empty header or tag never matches; callers that treat a missing
+// If-Match as unconditional must check for its presence first. See
+// [MatchETag] for the weak comparison used by If-None-Match.
+func MatchStrongETag(value, tag string) bool {
+	value, tag = strings.TrimSpace(value), strings.TrimSpace(tag)
+	if value == "" || tag == "" {
+		return false
+	}
+	if value == "*" {
+		return true
+	}
+	if strings.HasPrefix(tag, "W/") {
+		return false
+	}
+
+	for candidate := range fields(value, ',') {
+		if strings.TrimSpace(candidate) == tag {
+			return true
+		}
+	}
+	return false
+}
+
 // weak strips the weakness prefix from an entity tag, reducing it to the form
 // used for weak comparison.
 func weak(tag string) string {
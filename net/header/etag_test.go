@@ -61,6 +61,45 @@ func TestMatchETag(t *testing.T) {
 	}
 }
 
+func TestMatchStrongETag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		tag   string
+		want  bool
+	}{
+		{"exact", `"v1"`, `"v1"`, true},
+		{"mismatch", `"v2"`, `"v1"`, false},
+		{"wildcard", "*", `"v1"`, true},
+		{"wildcard, weak tag", "*", `W/"v1"`, true},
+		{"weak candidate", `W/"v1"`, `"v1"`, false},
+		{"weak tag", `"v1"`, `W/"v1"`, false},
+		{"weak both", `W/"v1"`, `W/"v1"`, false},
+		{"list, first", `"v1", "v2"`, `"v1"`, true},
+		{"list, last", `"v1", "v2"`, `"v2"`, true},
+		{"list, absent", `"v1", "v2"`, `"v3"`, false},
+		{"list, spaced", `  "v1" ,  "v2" `, `"v2"`, true},
+		{"list, weak member", `W/"v1", "v2"`, `"v1"`, false},
+		{"empty header", "", `"v1"`, false},
+		{"blank header", "   ", `"v1"`, false},
+		{"empty tag", `"v1"`, "", false},
+		{"unquoted", `v1`, `"v1"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := header.MatchStrongETag(tt.value, tt.tag)
+			if got != tt.want {
+				t.Errorf("got %t; want %t", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestQuote(t *testing.T) {
 	t.Parallel()
 
@@ -34,16 +34,80 @@ import (
 // path that escapes the directory it is joined to. The result is still
 // untrusted input and should not be used as a path without further checks.
 func Filename(h http.Header) string {
-	v := h.Get("Content-Disposition")
-	if v == "" {
-		return ""
+	_, params := ContentDisposition(h.Get("Content-Disposition"))
+	return basename(params["filename"])
+}
+
+// ContentDisposition parses the value of a Content-Disposition header into its
+// disposition type, such as "attachment" or "inline", and its parameters. The
+// type and parameter names are lowercased, and quoted values are unquoted.
+//
+// An RFC 5987 extended parameter such as filename* is decoded and reported
+// under its plain name, taking precedence over the plain parameter that
+// senders include as a fallback for older clients. Thus, the value
+//
+//	attachment; filename="EUR.pdf"; filename*=UTF-8''%e2%82%ac.pdf
+//
+// yields "attachment" and a filename of "€.pdf".
+//
+// It returns an empty type and a nil map if the value is empty or malformed.
+// The parameters are untrusted input; see [Filename] for a safe way to obtain
+// the filename.
+func ContentDisposition(
+	value string,
+) (dispType string, params map[string]string) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
 	}
-	_, params, err := mime.ParseMediaType(v)
+	dispType, params, err := mime.ParseMediaType(value)
 	if err != nil {
-		return ""
+		return "", nil
 	}
-	// The filename* parameter is decoded automatically.
-	return basename(params["filename"])
+	return dispType, params
+}
+
+// NewContentDisposition formats a Content-Disposition header value with the
+// given disposition type and filename, quoting and escaping as needed. It is
+// the counterpart of [ContentDisposition]:
+//
+//	w.Header().Set("Content-Disposition",
+//		header.NewContentDisposition("attachment", "report.pdf"),
+//	) // attachment; filename="report.pdf"
+//
+// A filename that contains non-ASCII characters is sent in the RFC 5987
+// filename* parameter, preceded by an ASCII approximation in the plain
+// filename parameter for clients that do not support the extended form. An
+// empty filename omits the parameter. It returns an empty string if dispType
+// is not a valid token.
+func NewContentDisposition(dispType, filename string) string {
+	if filename == "" {
+		return mime.FormatMediaType(dispType, nil)
+	}
+
+	plain := mime.FormatMediaType(dispType, map[string]string{
+		"filename": filename,
+	})
+	if plain == "" || !strings.Contains(plain, "filename*=") {
+		return plain
+	}
+
+	// The extended form was chosen, so the fallback is added in front of it.
+	fallback := mime.FormatMediaType(dispType, map[string]string{
+		"filename": printable(filename),
+	})
+	_, ext, _ := strings.Cut(plain, ";")
+	return fallback + ";" + ext
+}
+
+// printable approximates s in printable ASCII by replacing every other
+// character with an underscore.
+func printable(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, s)
 }
 
 // basename reduces a filename supplied by a remote party to its last path
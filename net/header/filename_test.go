@@ -15,6 +15,7 @@
 package header_test
 
 import (
+	"maps"
 	"net/http"
 	"strings"
 	"testing"
@@ -125,3 +126,117 @@ func TestFilename_Missing(t *testing.T) {
 		})
 	}
 }
+
+func TestContentDisposition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		give     string
+		wantType string
+		want     map[string]string
+	}{
+		{
+			"attachment",
+			`attachment; filename="report.pdf"`,
+			"attachment",
+			map[string]string{"filename": "report.pdf"},
+		},
+		{
+			"inline without params",
+			"Inline",
+			"inline",
+			map[string]string{},
+		},
+		{
+			"quoted separators",
+			`attachment; filename="a; b.pdf"`,
+			"attachment",
+			map[string]string{"filename": "a; b.pdf"},
+		},
+		{
+			"extended value",
+			`attachment; filename*=UTF-8''%E2%82%AC%20rates.pdf`,
+			"attachment",
+			map[string]string{"filename": "€ rates.pdf"},
+		},
+		{
+			"extended value takes precedence",
+			`attachment; filename="EUR.pdf"; filename*=UTF-8''%E2%82%AC.pdf`,
+			"attachment",
+			map[string]string{"filename": "€.pdf"},
+		},
+		{
+			"uppercase parameter",
+			`form-data; NAME="file"; Filename="x.txt"`,
+			"form-data",
+			map[string]string{"name": "file", "filename": "x.txt"},
+		},
+		{"empty", "", "", nil},
+		{"malformed", `attachment; filename="unterminated`, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			typ, params := header.ContentDisposition(tt.give)
+			if typ != tt.wantType {
+				t.Errorf("type: got %q; want %q", typ, tt.wantType)
+			}
+			if !maps.Equal(params, tt.want) {
+				t.Errorf("params: got %v; want %v", params, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewContentDisposition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dispType string
+		filename string
+		want     string
+	}{
+		{
+			"ascii",
+			"attachment", "report.pdf",
+			`attachment; filename=report.pdf`,
+		},
+		{
+			"quoted",
+			"attachment", `my "report".pdf`,
+			`attachment; filename="my \"report\".pdf"`,
+		},
+		{
+			"non-ascii",
+			"attachment", "€ rates.pdf",
+			`attachment; filename="_ rates.pdf"; ` +
+				`filename*=utf-8''%E2%82%AC%20rates.pdf`,
+		},
+		{"no filename", "inline", "", "inline"},
+		{"invalid type", "not a token", "x.pdf", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := header.NewContentDisposition(tt.dispType, tt.filename)
+			if got != tt.want {
+				t.Fatalf("got %q; want %q", got, tt.want)
+			}
+			if got == "" {
+				return
+			}
+
+			// The formatted value must parse back to the original name.
+			h := http.Header{"Content-Disposition": {got}}
+			if name := header.Filename(h); name != tt.filename {
+				t.Errorf("filename: got %q; want %q", name, tt.filename)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package header
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/deep-rent/nexus/std/ascii"
+)
+
+// ForwardedElement describes a single hop recorded in a Forwarded header, as
+// defined by RFC 7239. Parameters absent from the element are left empty.
+type ForwardedElement struct {
+	// For identifies the client that made the request to the proxy, such as
+	// "192.0.2.60", "[2001:db8::1]:4711", or an obfuscated "_hidden".
+	For string
+	// By identifies the interface on which the proxy received the request.
+	By string
+	// Host is the Host header of the request as received by the proxy.
+	Host string
+	// Proto is the protocol used to make the request, such as "https".
+	Proto string
+}
+
+// Forwarded parses all Forwarded headers in h into their elements, in the order
+// in which the proxies appended them, so that the first element describes the
+// original client. Values are unquoted, and commas or semicolons inside a
+// quoted value are preserved. Parameter names are matched case-insensitively;
+// unknown parameters and blank elements are skipped.
+//
+// The header can be set by any client, so an element is only trustworthy if
+// it was appended by a proxy under the control of the caller. Typically, that
+// means reading the elements from the end rather than trusting the first.
+func Forwarded(h http.Header) []ForwardedElement {
+	var elems []ForwardedElement
+	for _, line := range h.Values("Forwarded") {
+		for part := range fields(line, ',') {
+			var (
+				e     ForwardedElement
+				found bool
+			)
+			for pair := range fields(part, ';') {
+				k, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok {
+					continue
+				}
+				v = unquote(strings.TrimSpace(v))
+				switch ascii.ToLower(strings.TrimSpace(k)) {
+				case "for":
+					e.For, found = v, true
+				case "by":
+					e.By, found = v, true
+				case "host":
+					e.Host, found = v, true
+				case "proto":
+					e.Proto, found = ascii.ToLower(v), true
+				}
+			}
+			if found {
+				elems = append(elems, e)
+			}
+		}
+	}
+	return elems
+}
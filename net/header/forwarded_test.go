@@ -0,0 +1,91 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package header_test
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/deep-rent/nexus/net/header"
+)
+
+func TestForwarded(t *testing.T) {
+	t.Parallel()
+
+	type elem = header.ForwardedElement
+
+	tests := []struct {
+		name   string
+		values []string
+		want   []elem
+	}{
+		{"absent", nil, nil},
+		{"empty", []string{""}, nil},
+		{
+			"single",
+			[]string{"for=192.0.2.60;proto=http;by=203.0.113.43"},
+			[]elem{{For: "192.0.2.60", By: "203.0.113.43", Proto: "http"}},
+		},
+		{
+			"case-insensitive names",
+			[]string{"For=192.0.2.60; PROTO=HTTPS; Host=example.com"},
+			[]elem{{For: "192.0.2.60", Host: "example.com", Proto: "https"}},
+		},
+		{
+			"quoted ipv6",
+			[]string{`for="[2001:db8:cafe::17]:4711"`},
+			[]elem{{For: "[2001:db8:cafe::17]:4711"}},
+		},
+		{
+			"multiple elements",
+			[]string{"for=192.0.2.43, for=198.51.100.17;by=203.0.113.60"},
+			[]elem{
+				{For: "192.0.2.43"},
+				{For: "198.51.100.17", By: "203.0.113.60"},
+			},
+		},
+		{
+			"multiple headers",
+			[]string{"for=192.0.2.43", "for=198.51.100.17"},
+			[]elem{{For: "192.0.2.43"}, {For: "198.51.100.17"}},
+		},
+		{
+			"separators inside quotes",
+			[]string{`for="_a,b;c";host=example.com`},
+			[]elem{{For: "_a,b;c", Host: "example.com"}},
+		},
+		{
+			"unknown and malformed parameters",
+			[]string{"secret=x;for=192.0.2.43;bogus, ,ext=1"},
+			[]elem{{For: "192.0.2.43"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := http.Header{}
+			for _, v := range tt.values {
+				h.Add("Forwarded", v)
+			}
+
+			if got := header.Forwarded(h); !slices.Equal(got, tt.want) {
+				t.Errorf("got %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package header
 
 import (
+	"encoding/base64"
 	"iter"
 	"net/http"
 	"slices"
@@ -54,6 +55,12 @@ func Directives(s string) iter.Seq2[string, string] {
 // rate-limiting headers in the response. It reads the current time from the
 // given clock to calculate relative times. If no throttling is indicated, it
 // returns a duration of 0.
+//
+// A Retry-After header takes precedence. Otherwise, an exhausted quota is
+// recognized both in the conventional X-Ratelimit-* headers, where the reset
+// is given as a Unix timestamp, and in the RateLimit-* headers of the IETF
+// draft, where it is given in delta-seconds. If both indicate a delay, the
+// longer one is returned.
 func Throttle(h http.Header, now clock.Clock) time.Duration {
 	if v := h.Get("Retry-After"); v != "" {
 		if d, err := strconv.ParseInt(v, 10, 64); err == nil && d > 0 {
@@ -65,16 +72,21 @@ func Throttle(h http.Header, now clock.Clock) time.Duration {
 			}
 		}
 	}
+
+	var legacy, draft time.Duration
 	if h.Get("X-Ratelimit-Remaining") == "0" {
 		if v := h.Get("X-Ratelimit-Reset"); v != "" {
 			if t, err := strconv.ParseInt(v, 10, 64); err == nil && t > 0 {
-				if d := time.Unix(t, 0).Sub(now()); d > 0 {
-					return d
-				}
+				legacy = max(0, time.Unix(t, 0).Sub(now()))
 			}
 		}
 	}
-	return 0
+	if strings.TrimSpace(h.Get("RateLimit-Remaining")) == "0" {
+		if d, ok := seconds(strings.TrimSpace(h.Get("RateLimit-Reset"))); ok {
+			draft = d
+		}
+	}
+	return max(legacy, draft)
 }
 
 // Credentials extracts the credentials from the Authorization header of an
@@ -95,6 +107,28 @@ func Credentials(h http.Header, scheme string) string {
 	return credentials
 }
 
+// BasicAuth extracts the user name and password from the Authorization header
+// of an HTTP request that uses the Basic scheme defined by RFC 7617.
+//
+// The credentials are base64-decoded and split at the first colon, so the
+// password may itself contain colons. It reports ok=false if the header is
+// absent, uses a different scheme, or is not validly encoded.
+func BasicAuth(h http.Header) (user, pass string, ok bool) {
+	c := strings.TrimSpace(Credentials(h, "Basic"))
+	if c == "" {
+		return "", "", false
+	}
+	b, err := base64.StdEncoding.DecodeString(c)
+	if err != nil {
+		return "", "", false
+	}
+	user, pass, ok = strings.Cut(string(b), ":")
+	if !ok {
+		return "", "", false
+	}
+	return user, pass, true
+}
+
 // Preferences parses a header value with quality factors (e.g., Accept,
 // Accept-Encoding, Accept-Language) into an iterator quality factors (q-value)
 // by name (media range). The values are yielded in the order they appear in the
@@ -134,6 +168,49 @@ func Preferences(s string) iter.Seq2[string, float64] {
 // and global wildcards ("*/*" or "*"), returning true if the best match has
 // a q-value greater than zero.
 func Accepts(s, key string) bool {
+	return quality(Preferences(s), key) > 0
+}
+
+// Negotiate selects the offered value that best satisfies a header value with
+// quality factors (e.g., Accept, Accept-Encoding, or Accept-Language).
+//
+// Each offer is rated by its most specific match in the header, as described
+// for [Accepts], and the offer with the highest q-value wins. Ties are
+// resolved in favor of the offer listed first, so offered should be ordered by
+// the preference of the server. Offers that only match with a q-value of zero,
+// or not at all, are never selected. If no offer is acceptable, an empty
+// string is returned. An empty header value imposes no preference, in which
+// case the first offer is returned.
+//
+//	header.Negotiate("text/*;q=0.5, application/json", []string{
+//		"text/html",
+//		"application/json",
+//	}) // "application/json"
+func Negotiate(s string, offered []string) string {
+	if strings.TrimSpace(s) == "" {
+		if len(offered) == 0 {
+			return ""
+		}
+		return offered[0]
+	}
+
+	prefs := Preferences(s)
+
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, offer := range offered {
+		if q := quality(prefs, offer); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+// quality determines the q-value assigned to key by its most specific match
+// among the given preferences. It returns 0 if there is no match.
+func quality(prefs iter.Seq2[string, float64], key string) float64 {
 	var (
 		maxQ float64
 		maxP int
@@ -143,7 +220,7 @@ func Accepts(s, key string) bool {
 	// wildcards.
 	major, _, has := strings.Cut(key, "/")
 
-	for k, q := range Preferences(s) {
+	for k, q := range prefs {
 		var p int
 		switch {
 		case k == key:
@@ -160,10 +237,7 @@ func Accepts(s, key string) bool {
 			maxQ = q
 		}
 	}
-
-	// It is accepted if we found a valid match and its q-value is greater than
-	// 0.
-	return maxP > 0 && maxQ > 0
+	return maxQ
 }
 
 // MediaType extracts and returns the media type from a Content-Type header.
@@ -15,6 +15,7 @@
 package header_test
 
 import (
+	"encoding/base64"
 	"io"
 	"net/http"
 	"reflect"
@@ -150,6 +151,60 @@ func TestThrottle(t *testing.T) {
 			},
 			want: 0,
 		},
+		{
+			name: "ratelimit-reset delta seconds",
+			h: http.Header{
+				"Ratelimit-Remaining": {"0"},
+				"Ratelimit-Reset":     {"45"},
+			},
+			want: 45 * time.Second,
+		},
+		{
+			name: "ratelimit-remaining not zero",
+			h: http.Header{
+				"Ratelimit-Limit":     {"100"},
+				"Ratelimit-Remaining": {"3"},
+				"Ratelimit-Reset":     {"45"},
+			},
+			want: 0,
+		},
+		{
+			name: "ratelimit-reset invalid",
+			h: http.Header{
+				"Ratelimit-Remaining": {"0"},
+				"Ratelimit-Reset":     {"soon"},
+			},
+			want: 0,
+		},
+		{
+			name: "longer of both conventions",
+			h: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt(future.Unix(), 10)},
+				"Ratelimit-Remaining":   {"0"},
+				"Ratelimit-Reset":       {"10"},
+			},
+			want: 30 * time.Second,
+		},
+		{
+			name: "draft preferred when legacy expired",
+			h: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt(past.Unix(), 10)},
+				"Ratelimit-Remaining":   {"0"},
+				"Ratelimit-Reset":       {"10"},
+			},
+			want: 10 * time.Second,
+		},
+		{
+			name: "retry-after takes precedence",
+			h: http.Header{
+				"Retry-After":         {"5"},
+				"Ratelimit-Remaining": {"0"},
+				"Ratelimit-Reset":     {"45"},
+			},
+			want: 5 * time.Second,
+		},
 		{
 			name: "no headers",
 			h:    http.Header{},
@@ -315,6 +370,67 @@ func TestCredentials(t *testing.T) {
 	}
 }
 
+func TestBasicAuth(t *testing.T) {
+	t.Parallel()
+
+	// encode builds a Basic Authorization header from the given credentials.
+	encode := func(s string) http.Header {
+		return http.Header{
+			"Authorization": {"Basic " + base64.StdEncoding.EncodeToString(
+				[]byte(s),
+			)},
+		}
+	}
+
+	tests := []struct {
+		name string
+		h    http.Header
+		user string
+		pass string
+		ok   bool
+	}{
+		{"valid", encode("alice:secret"), "alice", "secret", true},
+		{"colon in password", encode("alice:a:b"), "alice", "a:b", true},
+		{"empty password", encode("alice:"), "alice", "", true},
+		{"missing colon", encode("alice"), "", "", false},
+		{
+			"lowercase scheme",
+			http.Header{"Authorization": {"basic YWxpY2U6c2VjcmV0"}},
+			"alice", "secret", true,
+		},
+		{
+			"invalid encoding",
+			http.Header{"Authorization": {"Basic not*base64"}},
+			"", "", false,
+		},
+		{
+			"other scheme",
+			http.Header{"Authorization": {"Bearer YWxpY2U6c2VjcmV0"}},
+			"", "", false,
+		},
+		{
+			"empty credentials",
+			http.Header{"Authorization": {"Basic "}},
+			"", "", false,
+		},
+		{"absent", http.Header{}, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			user, pass, ok := header.BasicAuth(tt.h)
+			if user != tt.user || pass != tt.pass || ok != tt.ok {
+				t.Errorf(
+					"got (%q, %q, %t); want (%q, %q, %t)",
+					user, pass, ok, tt.user, tt.pass, tt.ok,
+				)
+			}
+		})
+	}
+}
+
 func TestPreferences(t *testing.T) {
 	t.Parallel()
 
@@ -472,6 +588,58 @@ func TestAccepts(t *testing.T) {
 	}
 }
 
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	json, html, text := "application/json", "text/html", "text/plain"
+
+	tests := []struct {
+		name    string
+		value   string
+		offered []string
+		want    string
+	}{
+		{"exact", "application/json", []string{html, json}, json},
+		{
+			"highest quality", "text/html;q=0.5, application/json",
+			[]string{html, json}, json,
+		},
+		{
+			"tie prefers first offer", "text/html, application/json",
+			[]string{json, html}, json,
+		},
+		{"partial wildcard", "text/*", []string{json, text}, text},
+		{"global wildcard", "*/*;q=0.1", []string{json, html}, json},
+		{
+			"specific overrides wildcard", "text/*, text/html;q=0",
+			[]string{html, text}, text,
+		},
+		{"excluded", "application/json;q=0", []string{json}, ""},
+		{"no match", "image/png", []string{json, html}, ""},
+		{"empty header", "", []string{html, json}, html},
+		{"blank header", "  ", []string{html, json}, html},
+		{"no offers", "*/*", nil, ""},
+		{"empty header, no offers", "", nil, ""},
+		{
+			"encodings", "gzip;q=0.8, br, *;q=0.1",
+			[]string{"identity", "gzip"}, "gzip",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := header.Negotiate(tt.value, tt.offered); got != tt.want {
+				t.Errorf(
+					"for %q and offers %q: got %q; want %q",
+					tt.value, tt.offered, got, tt.want,
+				)
+			}
+		})
+	}
+}
+
 func TestMediaType(t *testing.T) {
 	t.Parallel()
 
@@ -33,16 +33,18 @@ import (
 // (no-cache="Set-Cookie") only marks those fields for revalidation and leaves
 // the lifetime intact.
 //
-// The time a response has already spent in upstream caches, as reported by
-// the Age header, is subtracted from a max-age. No such correction applies to
+// An s-maxage takes precedence over a max-age, as the consumers of this
+// function typically act as shared caches on behalf of many requests. The time
+// a response has already spent in upstream caches, as reported by the Age
+// header, is subtracted from either. No such correction applies to
 // Expires, which names an absolute instant and is therefore measured against
 // the clock directly.
 func Lifetime(h http.Header, now clock.Clock) time.Duration {
 	// Cache-Control takes precedence over Expires
 	if v := h.Get("Cache-Control"); v != "" {
 		var (
-			maxAge time.Duration
-			found  bool
+			maxAge, sMaxAge time.Duration
+			found, shared   bool
 		)
 
 		for k, v := range Directives(v) {
@@ -55,14 +57,19 @@ func Lifetime(h http.Header, now clock.Clock) time.Duration {
 					return 0
 				}
 			case "max-age":
-				if d, err := strconv.ParseInt(v, 10, 64); err == nil {
-					// A negative age denotes a response that is already
-					// stale, not one that expired in the past.
-					maxAge, found = max(0, time.Duration(d)*time.Second), true
+				if d, ok := seconds(v); ok {
+					maxAge, found = d, true
+				}
+			case "s-maxage":
+				if d, ok := seconds(v); ok {
+					sMaxAge, shared = d, true
 				}
 			}
 		}
 
+		if shared {
+			maxAge, found = sMaxAge, true
+		}
 		if found {
 			// What remains of the age budget after the time the response
 			// already spent being relayed.
@@ -79,6 +86,34 @@ func Lifetime(h http.Header, now clock.Clock) time.Duration {
 	return 0
 }
 
+// StaleWhileRevalidate reports the window past the lifetime of a response
+// during which it may still be served while a refresh happens in the
+// background, as granted by the stale-while-revalidate directive of the
+// Cache-Control header. It returns 0 if the directive is absent or malformed.
+// The caller is responsible for only applying the window to responses that
+// are cacheable in the first place; see [Lifetime].
+func StaleWhileRevalidate(h http.Header) time.Duration {
+	for k, v := range Directives(h.Get("Cache-Control")) {
+		if k == "stale-while-revalidate" {
+			if d, ok := seconds(v); ok {
+				return d
+			}
+		}
+	}
+	return 0
+}
+
+// seconds parses the value of a delta-seconds directive. A negative value
+// denotes a response that is already stale, not one that expired in the
+// past, and is therefore reported as 0.
+func seconds(v string) (time.Duration, bool) {
+	d, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return max(0, time.Duration(d)*time.Second), true
+}
+
 // Age reports how long a response has been held in caches on its way to the
 // client, as stated by the Age header. It returns 0 if the header is absent,
 // malformed, or negative.
@@ -187,6 +187,66 @@ func TestLifetime_IgnoresAgeForExpires(t *testing.T) {
 	}
 }
 
+// A shared max-age overrides the private one, regardless of order.
+func TestLifetime_SharedMaxAge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		give string
+		want time.Duration
+	}{
+		{"s-maxage only", "s-maxage=600", 10 * time.Minute},
+		{"s-maxage first", "s-maxage=600, max-age=60", 10 * time.Minute},
+		{"s-maxage last", "max-age=60, s-maxage=600", 10 * time.Minute},
+		{"malformed s-maxage", "max-age=60, s-maxage=soon", time.Minute},
+		{"negative s-maxage", "max-age=60, s-maxage=-1", 0},
+		{"no-store wins", "s-maxage=600, no-store", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := http.Header{"Cache-Control": []string{tt.give}}
+			if got := header.Lifetime(h, time.Now); got != tt.want {
+				t.Errorf("got %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		give string
+		want time.Duration
+	}{
+		{"absent", "", 0},
+		{"no directive", "max-age=60", 0},
+		{"present", "max-age=60, stale-while-revalidate=30", 30 * time.Second},
+		{"negative", "stale-while-revalidate=-30", 0},
+		{"malformed", "stale-while-revalidate=soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := http.Header{}
+			if tt.give != "" {
+				h.Set("Cache-Control", tt.give)
+			}
+
+			if got := header.StaleWhileRevalidate(h); got != tt.want {
+				t.Errorf("got %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAge(t *testing.T) {
 	t.Parallel()
 
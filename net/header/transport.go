@@ -15,6 +15,7 @@
 package header
 
 import (
+	"fmt"
 	"net/http"
 	"slices"
 )
@@ -58,3 +59,48 @@ func NewTransport(
 		headers: slices.Clone(headers),
 	}
 }
+
+// authTransport is an internal [http.RoundTripper] that injects a bearer
+// token obtained from a provider.
+type authTransport struct {
+	// wrapped is the underlying RoundTripper.
+	wrapped http.RoundTripper
+	// token yields the bearer token for each request.
+	token func() (string, error)
+}
+
+// RoundTrip clones the request and sets the Authorization header before
+// delegating.
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.token()
+	if err != nil {
+		// A RoundTripper must close the request body, even on errors.
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, fmt.Errorf("failed to obtain bearer token: %w", err)
+	}
+	clone := req.Clone(req.Context())
+	clone.Header.Set("Authorization", "Bearer "+token)
+	return t.wrapped.RoundTrip(clone)
+}
+
+var _ http.RoundTripper = (*authTransport)(nil)
+
+// NewAuthTransport wraps a base transport and sets an "Authorization: Bearer"
+// header on each outgoing request, using the token returned by the provider.
+//
+// The provider is called once per request, so it should cache the token
+// internally and refresh it only when it is about to expire; a cache.Controller
+// polling a token endpoint is one way to do so. If the provider fails, the
+// request is not sent and the error is returned from RoundTrip, wrapped.
+// Like [NewTransport], the request is cloned before it is modified.
+func NewAuthTransport(
+	t http.RoundTripper,
+	token func() (string, error),
+) http.RoundTripper {
+	return &authTransport{
+		wrapped: t,
+		token:   token,
+	}
+}
@@ -15,6 +15,7 @@
 package header_test
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 
@@ -139,3 +140,66 @@ func TestNewTransport_NoHeaders(t *testing.T) {
 		t.Error("got nil; want the base transport")
 	}
 }
+
+func TestNewAuthTransport(t *testing.T) {
+	t.Parallel()
+
+	var seen *http.Request
+	tokens := []string{"first", "second"}
+	var calls int
+	tr := header.NewAuthTransport(capture(&seen), func() (string, error) {
+		token := tokens[calls]
+		calls++
+		return token, nil
+	})
+
+	// The provider is consulted anew for every request.
+	for _, want := range []string{"Bearer first", "Bearer second"} {
+		req, err := http.NewRequestWithContext(
+			t.Context(), http.MethodGet, "http://example.com", nil,
+		)
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+
+		if _, err := tr.RoundTrip(req); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+
+		if got := seen.Header.Get("Authorization"); got != want {
+			t.Errorf("got %q; want %q", got, want)
+		}
+		if got := req.Header.Get("Authorization"); got != "" {
+			t.Errorf("caller's request was modified: got %q; want empty", got)
+		}
+	}
+}
+
+func TestNewAuthTransport_Error(t *testing.T) {
+	t.Parallel()
+
+	want := errors.New("token endpoint unavailable")
+
+	var seen *http.Request
+	tr := header.NewAuthTransport(capture(&seen), func() (string, error) {
+		return "", want
+	})
+
+	req, err := http.NewRequestWithContext(
+		t.Context(), http.MethodGet, "http://example.com", nil,
+	)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	res, err := tr.RoundTrip(req)
+	if !errors.Is(err, want) {
+		t.Errorf("got %v; want %v", err, want)
+	}
+	if res != nil {
+		t.Error("should not have returned a response")
+	}
+	if seen != nil {
+		t.Error("request should not have been sent")
+	}
+}
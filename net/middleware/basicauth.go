@@ -0,0 +1,75 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/deep-rent/nexus/net/header"
+)
+
+// BasicAuth returns a middleware [Pipe] that guards handlers with HTTP Basic
+// authentication as defined by RFC 7617.
+//
+// The credentials are read from the Authorization header and handed to
+// verify. Requests without valid credentials, or whose credentials verify
+// rejects, receive an empty 401 Unauthorized response carrying a
+// WWW-Authenticate challenge for the given realm, which prompts browsers to
+// ask for a login.
+//
+// Basic credentials travel in plain text, so the pipe should only be used
+// over TLS. The verify function should compare in constant time to avoid
+// leaking the secret through response timing; [StaticCredentials] does so
+// for a single fixed account.
+func BasicAuth(realm string, verify func(user, pass string) bool) Pipe {
+	challenge := `Basic realm="` + escaper.Replace(realm) +
+		`", charset="UTF-8"`
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, pass, ok := header.BasicAuth(r.Header)
+			if !ok || !verify(user, pass) {
+				w.Header().Set("WWW-Authenticate", challenge)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// escaper escapes a string for use inside an HTTP quoted-string.
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// StaticCredentials returns a verify function for [BasicAuth] that accepts
+// exactly one user name and password.
+//
+// Both values are compared in constant time, and neither their lengths nor
+// which of the two was wrong can be inferred from the time it takes.
+func StaticCredentials(user, pass string) func(user, pass string) bool {
+	wantUser := sha256.Sum256([]byte(user))
+	wantPass := sha256.Sum256([]byte(pass))
+	return func(user, pass string) bool {
+		// Hashing first gives both sides the same length; the results are
+		// combined without short-circuiting.
+		gotUser := sha256.Sum256([]byte(user))
+		gotPass := sha256.Sum256([]byte(pass))
+		u := subtle.ConstantTimeCompare(gotUser[:], wantUser[:])
+		p := subtle.ConstantTimeCompare(gotPass[:], wantPass[:])
+		return u&p == 1
+	}
+}
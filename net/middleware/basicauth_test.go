@@ -0,0 +1,99 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mw "github.com/deep-rent/nexus/net/middleware"
+)
+
+func TestBasicAuth(t *testing.T) {
+	t.Parallel()
+
+	const challenge = `Basic realm="admin \"area\"", charset="UTF-8"`
+
+	tests := []struct {
+		name      string
+		auth      func(r *http.Request)
+		wantCode  int
+		wantChall string
+	}{
+		{
+			name: "valid credentials",
+			auth: func(r *http.Request) {
+				r.SetBasicAuth("root", "s3:cret")
+			},
+			wantCode:  http.StatusOK,
+			wantChall: "",
+		},
+		{
+			name: "wrong password",
+			auth: func(r *http.Request) {
+				r.SetBasicAuth("root", "guess")
+			},
+			wantCode:  http.StatusUnauthorized,
+			wantChall: challenge,
+		},
+		{
+			name: "wrong user",
+			auth: func(r *http.Request) {
+				r.SetBasicAuth("admin", "s3:cret")
+			},
+			wantCode:  http.StatusUnauthorized,
+			wantChall: challenge,
+		},
+		{
+			name:      "missing credentials",
+			auth:      func(*http.Request) {},
+			wantCode:  http.StatusUnauthorized,
+			wantChall: challenge,
+		},
+		{
+			name: "other scheme",
+			auth: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer token")
+			},
+			wantCode:  http.StatusUnauthorized,
+			wantChall: challenge,
+		},
+	}
+
+	pipe := mw.BasicAuth(
+		`admin "area"`,
+		mw.StaticCredentials("root", "s3:cret"),
+	)
+	h := pipe(mockHandler)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			tt.auth(req)
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			if got := rr.Code; got != tt.wantCode {
+				t.Errorf("status code: got %d; want %d", got, tt.wantCode)
+			}
+			got := rr.Header().Get("WWW-Authenticate")
+			if got != tt.wantChall {
+				t.Errorf("challenge: got %q; want %q", got, tt.wantChall)
+			}
+		})
+	}
+}
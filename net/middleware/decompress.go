@@ -0,0 +1,119 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/deep-rent/nexus/std/ascii"
+)
+
+// DefaultDecompressLimit is the maximum size of a decompressed request body
+// unless overridden via [WithDecompressLimit].
+const DefaultDecompressLimit = 10 << 20 // 10 MiB
+
+// decompressConfig holds the configuration for the [Decompress] middleware.
+type decompressConfig struct {
+	// limit is the maximum number of decompressed bytes.
+	limit int64
+}
+
+// DecompressOption configures the [Decompress] middleware.
+type DecompressOption func(*decompressConfig)
+
+// WithDecompressLimit sets the maximum size of a decompressed request body in
+// bytes. Nonpositive values are ignored, keeping [DefaultDecompressLimit].
+func WithDecompressLimit(n int64) DecompressOption {
+	return func(c *decompressConfig) {
+		if n > 0 {
+			c.limit = n
+		}
+	}
+}
+
+// Decompress returns a middleware [Pipe] that transparently decodes request
+// bodies sent with "Content-Encoding: gzip" or "deflate".
+//
+// The body is replaced by a decompressing reader, and the Content-Encoding
+// and Content-Length headers are removed, so downstream handlers read plain
+// content of unknown length. Bodies in other encodings pass through
+// untouched. A body whose compressed stream cannot even be opened is rejected
+// with an empty 400 Bad Request response.
+//
+// A few kilobytes of compressed input can expand to gigabytes, so the
+// decompressed size is capped (see [WithDecompressLimit]). Reads beyond the
+// limit fail with an [http.MaxBytesError], which handlers should answer with
+// 413 Content Too Large, and the server closes the connection afterwards.
+func Decompress(opts ...DecompressOption) Pipe {
+	cfg := decompressConfig{limit: DefaultDecompressLimit}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			enc := strings.TrimSpace(r.Header.Get("Content-Encoding"))
+			if enc == "" || r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var (
+				zr  io.ReadCloser
+				err error
+			)
+			switch ascii.ToLower(enc) {
+			case "gzip", "x-gzip":
+				zr, err = gzip.NewReader(r.Body)
+			case "deflate":
+				// HTTP's "deflate" denotes the zlib format (RFC 9110 Section
+				// 8.4.1.2), not a raw deflate stream.
+				zr, err = zlib.NewReader(r.Body)
+			default:
+				next.ServeHTTP(w, r)
+				return
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			r = r.Clone(r.Context())
+			r.Header.Del("Content-Encoding")
+			r.Header.Del("Content-Length")
+			r.ContentLength = -1
+			r.Body = http.MaxBytesReader(w, &inflater{zr, r.Body}, cfg.limit)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// inflater is a request body that reads decompressed content and closes both
+// the decompressor and the original body.
+type inflater struct {
+	io.ReadCloser
+	// body is the original, compressed body.
+	body io.Closer
+}
+
+// Close closes the decompressor and the original body.
+func (i *inflater) Close() error {
+	return errors.Join(i.ReadCloser.Close(), i.body.Close())
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mw "github.com/deep-rent/nexus/net/middleware"
+)
+
+// compress encodes s with the given content coding.
+func compress(t *testing.T, enc, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch enc {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	default:
+		return []byte(s)
+	}
+	if _, err := io.WriteString(w, s); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// echo writes the request body and its remaining Content-Encoding back.
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Encoding", r.Header.Get("Content-Encoding"))
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		if _, ok := errors.AsType[*http.MaxBytesError](err); ok {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write(b)
+})
+
+func TestDecompress(t *testing.T) {
+	t.Parallel()
+
+	const payload = "the quick brown fox jumps over the lazy dog"
+
+	tests := []struct {
+		name     string
+		enc      string
+		wantEnc  string
+		wantBody string
+	}{
+		{"gzip", "gzip", "", payload},
+		{"deflate", "deflate", "", payload},
+		{"identity", "", "", payload},
+		{"unknown", "br", "br", payload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := mw.Decompress()(echo)
+
+			body := bytes.NewReader(compress(t, tt.enc, payload))
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			if tt.enc != "" {
+				req.Header.Set("Content-Encoding", tt.enc)
+			}
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			if got, want := rr.Code, http.StatusOK; got != want {
+				t.Errorf("status code: got %d; want %d", got, want)
+			}
+			if got := rr.Header().Get("X-Encoding"); got != tt.wantEnc {
+				t.Errorf("content-encoding: got %q; want %q", got, tt.wantEnc)
+			}
+			if got := rr.Body.String(); got != tt.wantBody {
+				t.Errorf("body: got %q; want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestDecompress_Malformed(t *testing.T) {
+	t.Parallel()
+
+	h := mw.Decompress()(echo)
+	req := httptest.NewRequest(
+		http.MethodPost, "/", strings.NewReader("not gzip"),
+	)
+	req.Header.Set("Content-Encoding", "gzip")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if got, want := rr.Code, http.StatusBadRequest; got != want {
+		t.Errorf("status code: got %d; want %d", got, want)
+	}
+}
+
+func TestWithDecompressLimit(t *testing.T) {
+	t.Parallel()
+
+	// A highly compressible body expands far beyond its encoded size.
+	bomb := compress(t, "gzip", strings.Repeat("a", 1<<16))
+
+	h := mw.Decompress(mw.WithDecompressLimit(1024))(echo)
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(bomb))
+	req.Header.Set("Content-Encoding", "gzip")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	if got, want := rr.Code, http.StatusRequestEntityTooLarge; got != want {
+		t.Errorf("status code: got %d; want %d", got, want)
+	}
+}
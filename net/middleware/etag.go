@@ -0,0 +1,185 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/deep-rent/nexus/net/header"
+)
+
+// ETag returns a middleware [Pipe] that derives a strong entity tag from the
+// response body and answers conditional GET requests with 304 Not Modified.
+//
+// The body of a 200 response to a GET request is buffered in full, and its
+// SHA-256 digest becomes the ETag header. If the request's If-None-Match
+// header matches that tag, as decided by [header.MatchETag], the body is
+// discarded and a bodiless 304 is sent instead. The client still pays for the
+// handler to run, but not for the transfer.
+//
+// Responses with another status, responses that already carry an ETag, and
+// responses that are flushed or hijacked before they complete are passed
+// through unchanged, as are requests with methods other than GET. Since the
+// full body is held in memory, the pipe suits small and medium-sized payloads
+// rather than large downloads. The tag describes the bytes as they reach this
+// pipe, so placing it inside a compressing middleware such as gzip tags the
+// uncompressed representation.
+func ETag() Pipe {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ew := &etagWriter{ResponseWriter: w}
+			next.ServeHTTP(ew, r)
+			ew.finish(r.Header.Get("If-None-Match"))
+		})
+	}
+}
+
+// etagWriter buffers the body of a 200 response so that an entity tag can be
+// computed from it.
+type etagWriter struct {
+	// ResponseWriter is the underlying writer being wrapped.
+	http.ResponseWriter
+	// buf holds the body written so far while buffering.
+	buf []byte
+	// wrote tracks if WriteHeader has been called.
+	wrote bool
+	// buffering tracks if the body is held back for tagging.
+	buffering bool
+}
+
+// WriteHeader starts buffering for a 200 response that has no ETag yet, and
+// forwards any other status directly.
+func (e *etagWriter) WriteHeader(code int) {
+	// Informational responses are forwarded without latching any state.
+	if code < 200 {
+		e.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if e.wrote {
+		return
+	}
+	e.wrote = true
+
+	if code == http.StatusOK && e.Header().Get("ETag") == "" {
+		e.buffering = true
+		return
+	}
+	e.ResponseWriter.WriteHeader(code)
+}
+
+// Write buffers the data while tagging, and forwards it otherwise.
+func (e *etagWriter) Write(b []byte) (int, error) {
+	if !e.wrote {
+		e.WriteHeader(http.StatusOK)
+	}
+	if e.buffering {
+		e.buf = append(e.buf, b...)
+		return len(b), nil
+	}
+	return e.ResponseWriter.Write(b)
+}
+
+// Flush implements [http.Flusher]. A flushed response is being streamed, so
+// tagging is abandoned and the buffered body is sent as is.
+func (e *etagWriter) Flush() {
+	if !e.wrote {
+		e.WriteHeader(http.StatusOK)
+	}
+	if e.buffering {
+		_ = e.release()
+	}
+	if flusher, ok := e.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer, so that
+// [http.NewResponseController] can reach optional interfaces implemented by
+// it.
+func (e *etagWriter) Unwrap() http.ResponseWriter {
+	return e.ResponseWriter
+}
+
+// Hijack implements [http.Hijacker] by delegating to the underlying writer.
+// The buffered body, if any, is discarded along with the response.
+func (e *etagWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := e.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijacking not supported")
+	}
+	e.wrote, e.buffering, e.buf = true, false, nil
+	return hijacker.Hijack()
+}
+
+// release stops buffering and sends the held-back status line along with the
+// buffered body.
+func (e *etagWriter) release() error {
+	e.buffering = false
+	e.ResponseWriter.WriteHeader(http.StatusOK)
+	buf := e.buf
+	e.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := e.ResponseWriter.Write(buf)
+	return err
+}
+
+// finish tags the buffered body once the handler has returned, and answers
+// with 304 Not Modified if the tag matches the If-None-Match value.
+func (e *etagWriter) finish(match string) {
+	if !e.wrote {
+		// A handler that writes nothing sends an empty 200 response.
+		e.WriteHeader(http.StatusOK)
+	}
+	if !e.buffering {
+		return
+	}
+
+	sum := sha256.Sum256(e.buf)
+	tag := header.Quote(base64.RawURLEncoding.EncodeToString(sum[:]))
+	h := e.Header()
+	h.Set("ETag", tag)
+
+	if header.MatchETag(match, tag) {
+		e.buffering, e.buf = false, nil
+		h.Del("Content-Length")
+		e.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if h.Get("Content-Length") == "" {
+		h.Set("Content-Length", strconv.Itoa(len(e.buf)))
+	}
+	_ = e.release()
+}
+
+// Ensure etagWriter implements the necessary contracts.
+var (
+	_ http.ResponseWriter = (*etagWriter)(nil)
+	_ http.Flusher        = (*etagWriter)(nil)
+	_ http.Hijacker       = (*etagWriter)(nil)
+)
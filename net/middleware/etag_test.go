@@ -0,0 +1,146 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mw "github.com/deep-rent/nexus/net/middleware"
+)
+
+func TestETag(t *testing.T) {
+	t.Parallel()
+
+	h := mw.ETag()(mockHandler)
+
+	// The first request learns the tag.
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got, want := rr.Code, http.StatusOK; got != want {
+		t.Fatalf("status code: got %d; want %d", got, want)
+	}
+	if got, want := rr.Body.String(), "ok"; got != want {
+		t.Errorf("body: got %q; want %q", got, want)
+	}
+	if got, want := rr.Header().Get("Content-Length"), "2"; got != want {
+		t.Errorf("content-length header: got %q; want %q", got, want)
+	}
+	tag := rr.Header().Get("ETag")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		t.Fatalf("etag header: got %q; want a strong tag", tag)
+	}
+
+	tests := []struct {
+		name     string
+		match    string
+		wantCode int
+		wantBody string
+	}{
+		{"matching tag", tag, http.StatusNotModified, ""},
+		{"weak matching tag", "W/" + tag, http.StatusNotModified, ""},
+		{"wildcard", "*", http.StatusNotModified, ""},
+		{"stale tag", `"stale"`, http.StatusOK, "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("If-None-Match", tt.match)
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			if got := rr.Code; got != tt.wantCode {
+				t.Errorf("status code: got %d; want %d", got, tt.wantCode)
+			}
+			if got := rr.Body.String(); got != tt.wantBody {
+				t.Errorf("body: got %q; want %q", got, tt.wantBody)
+			}
+			if got := rr.Header().Get("ETag"); got != tag {
+				t.Errorf("etag header: got %q; want %q", got, tag)
+			}
+		})
+	}
+}
+
+func TestETag_PassesThrough(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		method  string
+		handler http.HandlerFunc
+		want    string
+	}{
+		{
+			name:   "non-200 status",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte("ok"))
+			},
+			want: "",
+		},
+		{
+			name:   "existing tag",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				_, _ = w.Write([]byte("ok"))
+			},
+			want: `"v1"`,
+		},
+		{
+			name:   "streaming response",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("o"))
+				w.(http.Flusher).Flush()
+				_, _ = w.Write([]byte("k"))
+			},
+			want: "",
+		},
+		{
+			name:   "non-GET request",
+			method: http.MethodPost,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("ok"))
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h := mw.ETag()(tt.handler)
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			req.Header.Set("If-None-Match", "*")
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			if got := rr.Header().Get("ETag"); got != tt.want {
+				t.Errorf("etag header: got %q; want %q", got, tt.want)
+			}
+			if got, want := rr.Body.String(), "ok"; got != want {
+				t.Errorf("body: got %q; want %q", got, want)
+			}
+		})
+	}
+}
@@ -17,14 +17,16 @@
 // It compresses response payloads with the gzip algorithm for clients that
 // support it (indicated by the "Accept-Encoding" request header) and
 // automatically adds the "Content-Encoding: gzip" header. Responses that
-// already carry a Content-Encoding, bodiless statuses (204, 205, 304), and
-// HEAD requests are passed through untouched, and MIME types on the
-// exclusion list (media, fonts, and archives by default) are skipped.
+// already carry a Content-Encoding, bodiless statuses (204, 205, 304), range
+// responses (206 or a Content-Range header), and HEAD requests are passed
+// through untouched, and MIME types on the exclusion list (media, fonts, and
+// archives by default) are skipped.
 //
 // # Usage
 //
 // The middleware is designed to be efficient. It pools [gzip.Writer]
-// instances to reduce memory allocations.
+// instances to reduce memory allocations, and [WithMinSize] leaves responses
+// too small to benefit from compression untouched.
 //
 // Handlers should set Content-Type before the first write: the compression
 // decision is made when the headers are written, and without an explicit
@@ -21,6 +21,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -42,6 +43,14 @@ type interceptor struct {
 	exclude []string
 	// pool is the sync.Pool used for gzip writer reuse.
 	pool *sync.Pool
+	// minSize is the body size below which responses are not compressed.
+	minSize int
+	// buf holds the body written so far while the decision is pending.
+	buf []byte
+	// status is the status code held back while the decision is pending.
+	status int
+	// pending tracks if the decision awaits more of the body.
+	pending bool
 	// wrote tracks if WriteHeader has been called.
 	wrote bool
 	// hijacked tracks if the connection has been hijacked.
@@ -79,6 +88,13 @@ func (w *interceptor) WriteHeader(statusCode int) {
 		w.skip = true
 	}
 
+	// Byte ranges refer to the unencoded representation, so compressing a
+	// partial response would break them.
+	if statusCode == http.StatusPartialContent ||
+		w.Header().Get("Content-Range") != "" {
+		w.skip = true
+	}
+
 	mime := header.MediaType(w.Header())
 	if mime != "" {
 		for _, t := range w.exclude {
@@ -96,16 +112,60 @@ func (w *interceptor) WriteHeader(statusCode int) {
 		}
 	}
 
+	if !w.skip && w.minSize > 0 {
+		// A declared length settles the decision up front; otherwise, the
+		// status line is held back until enough of the body has arrived.
+		n, err := strconv.Atoi(w.Header().Get("Content-Length"))
+		if err == nil && n < w.minSize {
+			w.skip = true
+		} else if err != nil {
+			w.status = statusCode
+			w.pending = true
+			return
+		}
+	}
+
 	if !w.skip {
-		w.Header().Set("Content-Encoding", "gzip")
-		w.Header().Del("Content-Length")
-		w.gz = w.pool.Get().(*gzip.Writer)
-		w.gz.Reset(w.ResponseWriter)
+		w.start()
 	}
 
 	w.ResponseWriter.WriteHeader(statusCode)
 }
 
+// start announces the gzip encoding and acquires a writer from the pool. It
+// must be called before the status line is written.
+func (w *interceptor) start() {
+	w.Header().Set("Content-Encoding", "gzip")
+	w.Header().Del("Content-Length")
+	w.gz = w.pool.Get().(*gzip.Writer)
+	w.gz.Reset(w.ResponseWriter)
+}
+
+// release ends a pending decision and sends the held-back status line along
+// with the buffered body, compressed if zip is true.
+func (w *interceptor) release(zip bool) error {
+	w.pending = false
+	if zip {
+		w.start()
+	} else {
+		w.skip = true
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if zip {
+		_, err = w.gz.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
 // Write compresses the data and writes it to the underlying
 // [http.ResponseWriter].
 //
@@ -114,6 +174,16 @@ func (w *interceptor) Write(b []byte) (int, error) {
 	if !w.wrote {
 		w.WriteHeader(http.StatusOK)
 	}
+	if w.pending {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) < w.minSize {
+			return len(b), nil
+		}
+		if err := w.release(true); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
 	if w.skip {
 		return w.ResponseWriter.Write(b)
 	}
@@ -123,6 +193,10 @@ func (w *interceptor) Write(b []byte) (int, error) {
 // Close flushes buffered data, closes the gzip writer, and returns it to the
 // pool.
 func (w *interceptor) Close() {
+	// A body that stayed below the minimum size is sent as is.
+	if w.pending && !w.hijacked {
+		_ = w.release(false)
+	}
 	// If the connection was hijacked, don't write the gzip footer.
 	// Just return the writer to the pool.
 	if w.gz != nil {
@@ -159,6 +233,11 @@ func (w *interceptor) Flush() {
 	if !w.wrote {
 		w.WriteHeader(http.StatusOK)
 	}
+	// A streaming handler is unlikely to stay below the minimum size, so the
+	// pending decision is settled in favor of compression.
+	if w.pending {
+		_ = w.release(true)
+	}
 	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
 		if w.gz != nil {
 			_ = w.gz.Flush()
@@ -214,6 +293,7 @@ func New(opts ...Option) middleware.Pipe {
 				ResponseWriter: w,
 				exclude:        cfg.exclude,
 				pool:           pool,
+				minSize:        cfg.minSize,
 			}
 			defer gzw.Close()
 
@@ -372,6 +372,57 @@ func TestBodilessResponses(t *testing.T) {
 	}
 }
 
+func TestRangeResponses(t *testing.T) {
+	t.Parallel()
+
+	const part = "bytes 5-9"
+
+	tests := []struct {
+		name string
+		code int
+		rng  string
+	}{
+		{"partial content", http.StatusPartialContent, "bytes 0-4/10"},
+		{"content range", http.StatusOK, "bytes 0-9/10"},
+		{"unsatisfiable", http.StatusRequestedRangeNotSatisfiable, "bytes */10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := gzip.New()(http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "text/plain")
+					w.Header().Set("Content-Range", tt.rng)
+					w.Header().Set("Content-Length", "9")
+					w.WriteHeader(tt.code)
+					_, _ = w.Write([]byte(part))
+				},
+			))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept-Encoding", "gzip")
+			r.Header.Set("Range", "bytes=5-9")
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.code; got != want {
+				t.Fatalf("status code: got %d; want %d", got, want)
+			}
+			if got := w.Header().Get("Content-Encoding"); len(got) != 0 {
+				t.Errorf("content-encoding header: got %q; want empty", got)
+			}
+			if got, want := w.Header().Get("Content-Length"), "9"; got != want {
+				t.Errorf("content-length header: got %q; want %q", got, want)
+			}
+			if got := w.Body.String(); got != part {
+				t.Errorf("body: got %q; want %q", got, part)
+			}
+		})
+	}
+}
+
 func TestHeadRequest(t *testing.T) {
 	t.Parallel()
 
@@ -581,3 +632,104 @@ func TestFlusher(t *testing.T) {
 		t.Errorf("body: got %q; want %q", got, want)
 	}
 }
+
+func TestWithMinSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		writes  []string
+		length  string
+		flush   bool
+		wantZip bool
+	}{
+		{
+			name:    "passes small body through",
+			writes:  []string{"tiny"},
+			wantZip: false,
+		},
+		{
+			name:    "passes empty body through",
+			writes:  nil,
+			wantZip: false,
+		},
+		{
+			name:    "compresses body reaching the threshold",
+			writes:  []string{"0123456789", "0123456789"},
+			wantZip: true,
+		},
+		{
+			name:    "honors small declared length",
+			writes:  []string{"tiny"},
+			length:  "4",
+			wantZip: false,
+		},
+		{
+			name:    "compresses on flush",
+			writes:  []string{"tiny"},
+			flush:   true,
+			wantZip: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := gzip.New(gzip.WithMinSize(16))(http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "text/plain")
+					if tt.length != "" {
+						w.Header().Set("Content-Length", tt.length)
+					}
+					w.WriteHeader(http.StatusCreated)
+					for _, s := range tt.writes {
+						if _, err := w.Write([]byte(s)); err != nil {
+							t.Errorf("should not have returned an error: %v", err)
+						}
+					}
+					if tt.flush {
+						w.(http.Flusher).Flush()
+					}
+				},
+			))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept-Encoding", "gzip")
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, http.StatusCreated; got != want {
+				t.Errorf("status code: got %d; want %d", got, want)
+			}
+
+			var want string
+			for _, s := range tt.writes {
+				want += s
+			}
+
+			var body io.Reader = w.Body
+			if tt.wantZip {
+				if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+					t.Fatalf("content-encoding header: got %q; want %q",
+						got, "gzip")
+				}
+				gzr, err := compress.NewReader(w.Body)
+				if err != nil {
+					t.Fatalf("should not have returned an error: %v", err)
+				}
+				body = gzr
+			} else if got := w.Header().Get("Content-Encoding"); got != "" {
+				t.Errorf("content-encoding header: got %q; want empty", got)
+			}
+
+			data, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+			if got := string(data); got != want {
+				t.Errorf("body: got %q; want %q", got, want)
+			}
+		})
+	}
+}
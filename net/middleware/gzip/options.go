@@ -54,6 +54,8 @@ type config struct {
 	level int
 	// exclude is the list of MIME types to skip.
 	exclude []string
+	// minSize is the body size below which responses are not compressed.
+	minSize int
 }
 
 // Option is a function that configures the middleware.
@@ -88,3 +90,18 @@ func WithExcludeMimeTypes(types ...string) Option {
 		}
 	}
 }
+
+// WithMinSize sets the body size, in bytes, below which responses are sent
+// uncompressed, since compressing tiny payloads costs CPU time and can even
+// make them larger.
+//
+// The interceptor buffers the beginning of the body, holding back the status
+// line until n bytes have been written or the handler returns. A declared
+// Content-Length settles the decision without buffering, and flushing the
+// response settles it in favor of compression. Nonpositive values disable the
+// threshold, which is the default.
+func WithMinSize(n int) Option {
+	return func(c *config) {
+		c.minSize = max(0, n)
+	}
+}
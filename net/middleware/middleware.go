@@ -112,12 +112,18 @@ var requestIDKey contextKey
 // unless overridden via [WithRequestIDHeader].
 const DefaultRequestIDHeader = "X-Request-ID"
 
+// DefaultRequestIDLength is the number of characters in a generated request
+// ID unless overridden via [WithRequestIDLength].
+const DefaultRequestIDLength = 32
+
 // requestIDConfig holds the configuration for the [RequestID] middleware.
 type requestIDConfig struct {
 	// header is the name of the request and response ID header.
 	header string
 	// trustClient reuses an inbound ID instead of generating a fresh one.
 	trustClient bool
+	// length is the number of characters in a generated ID.
+	length int
 }
 
 // RequestIDOption configures the [RequestID] middleware.
@@ -134,6 +140,19 @@ func WithRequestIDHeader(name string) RequestIDOption {
 	}
 }
 
+// WithRequestIDLength sets the number of hexadecimal characters in generated
+// request IDs. Shorter IDs are easier to read in logs but more likely to
+// collide; the default of [DefaultRequestIDLength] carries 128 random bits.
+// Values outside the range 1 to 64 are ignored, matching the limit applied to
+// inbound IDs.
+func WithRequestIDLength(n int) RequestIDOption {
+	return func(c *requestIDConfig) {
+		if n > 0 && n <= 64 {
+			c.length = n
+		}
+	}
+}
+
 // WithTrustClient reuses a request ID supplied by the client.
 //
 // When enabled and the inbound request carries a syntactically valid ID in
@@ -173,10 +192,15 @@ func validRequestID(id string) bool {
 // It adds the ID to the response via the "X-Request-ID" header (configurable
 // through [WithRequestIDHeader]) and to the request's context for downstream
 // use. Downstream handlers and other middleware can retrieve the ID using
-// [GetRequestID]. By default a fresh random ID is generated for every
-// request; see [WithTrustClient] for propagating gateway-assigned IDs.
+// [GetRequestID]. By default a fresh random ID of [DefaultRequestIDLength]
+// hexadecimal characters is generated for every request; see
+// [WithRequestIDLength] for shorter IDs and [WithTrustClient] for propagating
+// gateway-assigned IDs.
 func RequestID(opts ...RequestIDOption) Pipe {
-	cfg := requestIDConfig{header: DefaultRequestIDHeader}
+	cfg := requestIDConfig{
+		header: DefaultRequestIDHeader,
+		length: DefaultRequestIDLength,
+	}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
@@ -190,9 +214,9 @@ func RequestID(opts ...RequestIDOption) Pipe {
 			}
 			if id == "" {
 				// Note: crypto/rand.Read is guaranteed not to fail.
-				b := make([]byte, 16)
+				b := make([]byte, (cfg.length+1)/2)
 				_, _ = rand.Read(b)
-				id = hex.EncodeToString(b)
+				id = hex.EncodeToString(b)[:cfg.length]
 			}
 			w.Header().Set(cfg.header, id)
 			next.ServeHTTP(w, r.WithContext(SetRequestID(r.Context(), id)))
@@ -279,7 +303,9 @@ var (
 // the request has been handled. It includes the method, URL, status code,
 // response size, duration, and other common attributes. To include a request
 // ID in the log, this middleware should be placed after the [RequestID]
-// middleware in the chain.
+// middleware in the chain. The matched route pattern is included as well,
+// provided the [http.ServeMux] stamped it onto the request seen by Log, which
+// holds when no middleware in between replaces the request.
 //
 // If the logger has the debug level disabled, Log returns nil, which [Chain]
 // (and the router's Adapt) skip entirely, so a disabled logger adds no chaining
@@ -301,6 +327,7 @@ func Log(logger *log.Logger) Pipe {
 				log.String("id", GetRequestID(r.Context())),
 				log.String("method", r.Method),
 				log.String("url", r.URL.String()),
+				log.String("pattern", r.Pattern),
 				log.String("remote", r.RemoteAddr),
 				log.String("user_agent", r.UserAgent()),
 				log.Int("status", incpt.statusCode),
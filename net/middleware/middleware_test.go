@@ -310,6 +310,40 @@ func TestRequestID(t *testing.T) {
 		}
 	})
 
+	t.Run("custom length", func(t *testing.T) {
+		t.Parallel()
+		for _, n := range []int{16, 7} {
+			var captured string
+			h := mw.RequestID(mw.WithRequestIDLength(n))(trap(&captured))
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if got := len(captured); got != n {
+				t.Errorf("id length: got %d; want %d", got, n)
+			}
+			if got := rr.Header().Get("X-Request-ID"); got != captured {
+				t.Errorf("header id: got %q; want %q", got, captured)
+			}
+		}
+	})
+
+	t.Run("ignores invalid length", func(t *testing.T) {
+		t.Parallel()
+		for _, n := range []int{0, -1, 65} {
+			var captured string
+			h := mw.RequestID(mw.WithRequestIDLength(n))(trap(&captured))
+			h.ServeHTTP(
+				httptest.NewRecorder(),
+				httptest.NewRequest(http.MethodGet, "/", nil),
+			)
+
+			want := mw.DefaultRequestIDLength
+			if got := len(captured); got != want {
+				t.Errorf("for %d: got id length %d; want %d", n, got, want)
+			}
+		}
+	})
+
 	t.Run("custom header", func(t *testing.T) {
 		t.Parallel()
 		var captured string
@@ -404,6 +438,26 @@ func TestLog(t *testing.T) {
 		}
 	})
 
+	t.Run("logs the matched pattern", func(t *testing.T) {
+		t.Parallel()
+		logger, buf := mockLogger()
+		mux := http.NewServeMux()
+		mux.Handle("GET /users/{id}", mockHandler)
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		mw.Log(logger)(mux).ServeHTTP(rr, req)
+
+		lines := buf.Lines()
+		if got, want := len(lines), 1; got != want {
+			t.Fatalf("log lines: got %d; want %d", got, want)
+		}
+		rec := parseRecord(t, lines[0])
+		if got, want := rec["pattern"], "GET /users/{id}"; got != want {
+			t.Errorf("for key %q: got %v; want %v", "pattern", got, want)
+		}
+	})
+
 	t.Run("preserves flusher", func(t *testing.T) {
 		t.Parallel()
 		logger, _ := mockLogger()
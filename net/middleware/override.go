@@ -0,0 +1,82 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/deep-rent/nexus/net/header"
+	"github.com/deep-rent/nexus/std/ascii"
+)
+
+// MethodOverrideHeader is the request header consulted by [MethodOverride].
+const MethodOverrideHeader = "X-HTTP-Method-Override"
+
+// MethodOverrideField is the form field consulted by [MethodOverride].
+const MethodOverrideField = "_method"
+
+// MethodOverride returns a middleware [Pipe] that lets clients limited to GET
+// and POST, such as HTML forms, tunnel other methods through POST.
+//
+// For POST requests, the intended method is taken from the
+// "X-HTTP-Method-Override" header or, failing that, from the "_method" field
+// of a URL-encoded form body. Only PUT, PATCH, and DELETE are accepted; any
+// other value leaves the method untouched. The method is rewritten on a copy
+// of the request before it proceeds, so the pipe must run ahead of the
+// router to affect route matching, while outer middleware keeps seeing the
+// original method.
+//
+// Security considerations:
+//
+//   - An override turns a POST into a state-changing request that a
+//     cross-site HTML form can trigger, so CSRF protection has to cover the
+//     overridden methods as well, not just POST.
+//   - Overrides to safe methods such as GET are refused, because a request
+//     with a body must not be treated as safe or cacheable.
+//   - Reading the form field parses the request body. Only URL-encoded forms
+//     are parsed, and the parsed values remain available via r.PostForm.
+func MethodOverride() Pipe {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				// The request is copied so that outer middleware, such as
+				// Log, still observes the method the client sent.
+				r = r.Clone(r.Context())
+				if m, ok := override(r); ok {
+					r.Method = m
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// override extracts the method that a POST request asks to be treated as,
+// reporting whether it is among the permitted ones.
+func override(r *http.Request) (string, bool) {
+	m := r.Header.Get(MethodOverrideHeader)
+	if m == "" &&
+		header.MediaType(r.Header) == "application/x-www-form-urlencoded" {
+		if err := r.ParseForm(); err == nil {
+			m = r.PostForm.Get(MethodOverrideField)
+		}
+	}
+	switch m = ascii.ToUpper(m); m {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return m, true
+	default:
+		return "", false
+	}
+}
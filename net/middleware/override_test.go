@@ -0,0 +1,123 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mw "github.com/deep-rent/nexus/net/middleware"
+)
+
+func TestMethodOverride(t *testing.T) {
+	t.Parallel()
+
+	const form = "application/x-www-form-urlencoded"
+
+	tests := []struct {
+		name   string
+		method string
+		header string
+		ctype  string
+		body   string
+		want   string
+	}{
+		{
+			name:   "header",
+			method: http.MethodPost,
+			header: "DELETE",
+			want:   http.MethodDelete,
+		},
+		{
+			name:   "lowercase header",
+			method: http.MethodPost,
+			header: "patch",
+			want:   http.MethodPatch,
+		},
+		{
+			name:   "form field",
+			method: http.MethodPost,
+			ctype:  form,
+			body:   "_method=PUT&name=x",
+			want:   http.MethodPut,
+		},
+		{
+			name:   "header takes precedence",
+			method: http.MethodPost,
+			header: "DELETE",
+			ctype:  form,
+			body:   "_method=PUT",
+			want:   http.MethodDelete,
+		},
+		{
+			name:   "ignores other content types",
+			method: http.MethodPost,
+			ctype:  "application/json",
+			body:   `{"_method":"PUT"}`,
+			want:   http.MethodPost,
+		},
+		{
+			name:   "refuses safe methods",
+			method: http.MethodPost,
+			header: "GET",
+			want:   http.MethodPost,
+		},
+		{
+			name:   "refuses unknown methods",
+			method: http.MethodPost,
+			header: "PURGE",
+			want:   http.MethodPost,
+		},
+		{
+			name:   "ignores non-POST requests",
+			method: http.MethodGet,
+			header: "DELETE",
+			want:   http.MethodGet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got string
+			h := mw.MethodOverride()(http.HandlerFunc(
+				func(_ http.ResponseWriter, r *http.Request) {
+					got = r.Method
+				},
+			))
+
+			req := httptest.NewRequest(
+				tt.method, "/", strings.NewReader(tt.body),
+			)
+			if tt.header != "" {
+				req.Header.Set(mw.MethodOverrideHeader, tt.header)
+			}
+			if tt.ctype != "" {
+				req.Header.Set("Content-Type", tt.ctype)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("method: got %q; want %q", got, tt.want)
+			}
+			if req.Method != tt.method {
+				t.Errorf("original method: got %q; want %q",
+					req.Method, tt.method)
+			}
+		})
+	}
+}
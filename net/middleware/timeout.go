@@ -0,0 +1,207 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"maps"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Timeout returns a middleware [Pipe] that bounds each request by a deadline.
+//
+// The request context is replaced by one that expires after d, so handlers
+// and the calls they make should watch r.Context() and give up once it is
+// done. If the deadline passes before the handler has started its response,
+// the client receives an empty 504 Gateway Timeout right away, even if the
+// handler ignores its context and keeps running. Writes that the handler
+// attempts afterwards fail with [http.ErrHandlerTimeout] instead of reaching
+// the client, so the response is never written twice. A handler that has
+// already started responding is left to finish on its own; only its context
+// is canceled.
+//
+// Unlike [http.TimeoutHandler], the handler runs on the calling goroutine and
+// its output is not buffered, so streaming, flushing, and hijacking keep
+// working. Headers set by the handler are staged until it writes the status
+// line, which keeps them out of the 504 response.
+//
+// If d is not positive, Timeout returns nil, which [Chain] (and the router's
+// Adapt) skip entirely.
+func Timeout(d time.Duration) Pipe {
+	if d <= 0 {
+		return nil
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			tw := &timeoutWriter{w: w, h: w.Header().Clone(), ctx: ctx}
+			stop := context.AfterFunc(ctx, tw.expire)
+			defer stop()
+			// Once the handler has returned, the response belongs to the
+			// server again and must not be touched by a late timeout.
+			defer tw.finish()
+
+			next.ServeHTTP(tw, r.WithContext(ctx))
+		})
+	}
+}
+
+// timeoutWriter serializes access to an [http.ResponseWriter] shared between
+// the handler and the timer of the [Timeout] middleware.
+type timeoutWriter struct {
+	// w is the original writer.
+	w http.ResponseWriter
+	// h stages the headers set by the handler until the response starts.
+	h http.Header
+	// ctx is the request context carrying the deadline.
+	ctx context.Context
+	// mu guards the fields below and all access to w.
+	mu sync.Mutex
+	// wrote tracks whether the response has been started, by either side.
+	wrote bool
+	// timedOut tracks whether the deadline passed before the response started.
+	timedOut bool
+	// done tracks whether the handler has returned.
+	done bool
+}
+
+// Header returns the staged header map of the handler.
+func (t *timeoutWriter) Header() http.Header { return t.h }
+
+// WriteHeader commits the staged headers and the status code, unless the
+// response has already been started.
+func (t *timeoutWriter) WriteHeader(code int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.writeHeader(code)
+}
+
+// writeHeader implements WriteHeader; the caller must hold the lock.
+func (t *timeoutWriter) writeHeader(code int) {
+	if t.wrote || t.expired() {
+		return
+	}
+	// Informational responses are forwarded without latching any state.
+	if code >= 200 {
+		t.wrote = true
+	}
+	dst := t.w.Header()
+	clear(dst)
+	maps.Copy(dst, t.h)
+	t.w.WriteHeader(code)
+}
+
+// Write writes the data to the underlying writer, or fails with
+// [http.ErrHandlerTimeout] if the deadline has passed before the response
+// started.
+func (t *timeoutWriter) Write(b []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.expired() {
+		return 0, http.ErrHandlerTimeout
+	}
+	t.writeHeader(http.StatusOK)
+	return t.w.Write(b)
+}
+
+// Flush implements [http.Flusher] by delegating to the underlying writer.
+func (t *timeoutWriter) Flush() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.expired() {
+		return
+	}
+	// Flushing transmits the headers, so they must be committed first.
+	t.writeHeader(http.StatusOK)
+	if flusher, ok := t.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer, so that
+// [http.NewResponseController] can reach optional interfaces implemented by
+// it.
+func (t *timeoutWriter) Unwrap() http.ResponseWriter {
+	return t.w
+}
+
+// Hijack implements [http.Hijacker] by delegating to the underlying writer.
+// A hijacked connection is no longer subject to the timeout response.
+func (t *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.expired() {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+	hijacker, ok := t.w.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijacking not supported")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		t.wrote = true
+	}
+	return conn, rw, err
+}
+
+// expire sends the timeout response if the deadline has passed before the
+// response was started. It is called once the request context is done.
+func (t *timeoutWriter) expire() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expired()
+}
+
+// expired reports whether the deadline has passed before the response was
+// started, sending the timeout response on first detection. The handler may
+// observe the expired context before the timer does, so every write checks
+// again. The caller must hold the lock.
+func (t *timeoutWriter) expired() bool {
+	if t.timedOut {
+		return true
+	}
+	if t.wrote || t.done ||
+		!errors.Is(t.ctx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+	t.timedOut = true
+	// The handler may ignore its context and keep running for a long time,
+	// so the bodiless response is flushed to make it reach the client now.
+	t.w.Header().Set("Content-Length", "0")
+	t.w.WriteHeader(http.StatusGatewayTimeout)
+	_ = http.NewResponseController(t.w).Flush()
+	return true
+}
+
+// finish marks the handler as returned.
+func (t *timeoutWriter) finish() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.done = true
+}
+
+// Ensure timeoutWriter implements the necessary contracts.
+var (
+	_ http.ResponseWriter = (*timeoutWriter)(nil)
+	_ http.Flusher        = (*timeoutWriter)(nil)
+	_ http.Hijacker       = (*timeoutWriter)(nil)
+)
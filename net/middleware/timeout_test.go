@@ -0,0 +1,166 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mw "github.com/deep-rent/nexus/net/middleware"
+)
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("passes fast responses through", func(t *testing.T) {
+		t.Parallel()
+		h := mw.Timeout(time.Second)(mockHandler)
+
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if got, want := rr.Code, http.StatusOK; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+		if got, want := rr.Body.String(), "ok"; got != want {
+			t.Errorf("body: got %q; want %q", got, want)
+		}
+	})
+
+	t.Run("responds with 504 once the deadline passes", func(t *testing.T) {
+		t.Parallel()
+		var werr error
+		h := mw.Timeout(10 * time.Millisecond)(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+				w.Header().Set("X-Late", "true")
+				w.WriteHeader(http.StatusOK)
+				_, werr = w.Write([]byte("late"))
+			},
+		))
+
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if got, want := rr.Code, http.StatusGatewayTimeout; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+		if got := rr.Body.String(); got != "" {
+			t.Errorf("body: got %q; want empty", got)
+		}
+		if got := rr.Header().Get("X-Late"); got != "" {
+			t.Errorf("header: got %q; want empty", got)
+		}
+		if !errors.Is(werr, http.ErrHandlerTimeout) {
+			t.Errorf("write error: got %v; want %v",
+				werr, http.ErrHandlerTimeout)
+		}
+	})
+
+	t.Run("sends the 504 while the handler still runs", func(t *testing.T) {
+		t.Parallel()
+		release := make(chan struct{})
+		h := mw.Timeout(20 * time.Millisecond)(http.HandlerFunc(
+			func(http.ResponseWriter, *http.Request) {
+				<-release // Ignores the context.
+			},
+		))
+		srv := httptest.NewServer(h)
+		defer srv.Close()
+		defer close(release)
+
+		client := &http.Client{Timeout: 5 * time.Second}
+		res, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		_ = res.Body.Close()
+
+		if got, want := res.StatusCode, http.StatusGatewayTimeout; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+	})
+
+	t.Run("leaves a started response alone", func(t *testing.T) {
+		t.Parallel()
+		h := mw.Timeout(10 * time.Millisecond)(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				<-r.Context().Done()
+				_, _ = w.Write([]byte("done"))
+			},
+		))
+
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if got, want := rr.Code, http.StatusOK; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+		if got, want := rr.Body.String(), "done"; got != want {
+			t.Errorf("body: got %q; want %q", got, want)
+		}
+	})
+
+	t.Run("sets a deadline on the request context", func(t *testing.T) {
+		t.Parallel()
+		var ok bool
+		h := mw.Timeout(time.Minute)(http.HandlerFunc(
+			func(_ http.ResponseWriter, r *http.Request) {
+				_, ok = r.Context().Deadline()
+			},
+		))
+
+		h.ServeHTTP(
+			httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, "/", nil),
+		)
+
+		if !ok {
+			t.Error("request context should have a deadline")
+		}
+	})
+
+	t.Run("ignores cancellation by the client", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(t.Context())
+		h := mw.Timeout(time.Minute)(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				cancel()
+				<-r.Context().Done()
+				w.WriteHeader(http.StatusAccepted)
+			},
+		))
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+		h.ServeHTTP(rr, req)
+
+		if got, want := rr.Code, http.StatusAccepted; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+	})
+
+	t.Run("returns nil for nonpositive durations", func(t *testing.T) {
+		t.Parallel()
+		if pipe := mw.Timeout(0); pipe != nil {
+			t.Error("got a pipe; want nil")
+		}
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+)
+
+// Balancer selects the upstream target for each request proxied by a handler
+// created with [NewBalancedHandler]. Implementations must be safe for
+// concurrent use.
+type Balancer interface {
+	// Next returns the target to route r to. The returned function, which
+	// must not be nil, is called once the upstream exchange has completed,
+	// allowing the balancer to track in-flight requests.
+	Next(r *http.Request) (target *url.URL, done func())
+}
+
+// BalancerFactory creates a [Balancer] that distributes requests across the
+// given, non-empty list of targets.
+type BalancerFactory = func(targets []*url.URL) Balancer
+
+// RoundRobin is the default [BalancerFactory]. Its balancer cycles through the
+// targets in order, giving each the same share of requests.
+func RoundRobin(targets []*url.URL) Balancer {
+	return &roundRobin{targets: targets}
+}
+
+// Random is a [BalancerFactory] whose balancer picks a target uniformly at
+// random for each request.
+func Random(targets []*url.URL) Balancer {
+	return &random{targets: targets}
+}
+
+// LeastConnections is a [BalancerFactory] whose balancer routes each request
+// to the target with the fewest requests in flight. Ties are broken in
+// round-robin order. This suits upstreams whose response times vary widely,
+// such as those serving long-polling or streaming requests.
+func LeastConnections(targets []*url.URL) Balancer {
+	return &leastConnections{
+		targets: targets,
+		active:  make([]atomic.Int64, len(targets)),
+	}
+}
+
+// noop is the completion callback of balancers that keep no per-request
+// state.
+func noop() {}
+
+// roundRobin implements the [RoundRobin] strategy.
+type roundRobin struct {
+	targets []*url.URL
+	// next counts the requests routed so far.
+	next atomic.Uint64
+}
+
+// Next implements [Balancer].
+func (b *roundRobin) Next(*http.Request) (*url.URL, func()) {
+	i := (b.next.Add(1) - 1) % uint64(len(b.targets))
+	return b.targets[i], noop
+}
+
+// random implements the [Random] strategy.
+type random struct {
+	targets []*url.URL
+}
+
+// Next implements [Balancer].
+func (b *random) Next(*http.Request) (*url.URL, func()) {
+	return b.targets[rand.IntN(len(b.targets))], noop
+}
+
+// leastConnections implements the [LeastConnections] strategy.
+type leastConnections struct {
+	targets []*url.URL
+	// active counts the requests in flight per target.
+	active []atomic.Int64
+	// next rotates the starting point of the scan to break ties.
+	next atomic.Uint64
+}
+
+// Next implements [Balancer].
+func (b *leastConnections) Next(*http.Request) (*url.URL, func()) {
+	n := uint64(len(b.targets))
+	off := b.next.Add(1) - 1
+	best := off % n
+	for k := uint64(1); k < n; k++ {
+		i := (off + k) % n
+		if b.active[i].Load() < b.active[best].Load() {
+			best = i
+		}
+	}
+	// The counts may change between the scan and the increment, so the choice
+	// is approximate under contention, which is good enough for balancing.
+	b.active[best].Add(1)
+	return b.targets[best], func() { b.active[best].Add(-1) }
+}
+
+// Ensure the strategies implement the Balancer interface.
+var (
+	_ Balancer = (*roundRobin)(nil)
+	_ Balancer = (*random)(nil)
+	_ Balancer = (*leastConnections)(nil)
+)
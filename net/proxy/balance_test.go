@@ -0,0 +1,162 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/deep-rent/nexus/net/proxy"
+)
+
+func mustParse(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parsing %q: should not have returned an error: %v", raw, err)
+	}
+	return u
+}
+
+func TestNewBalancedHandler(t *testing.T) {
+	t.Parallel()
+
+	var targets []*url.URL
+	for _, name := range []string{"a", "b", "c"} {
+		srv := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, name+r.URL.Path)
+			},
+		))
+		defer srv.Close()
+		targets = append(targets, mustParse(t, srv.URL))
+	}
+
+	srv := httptest.NewServer(proxy.NewBalancedHandler(targets))
+	defer srv.Close()
+
+	var got []string
+	for range 6 {
+		res, err := http.Get(srv.URL + "/x")
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		b, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		got = append(got, string(b))
+	}
+
+	want := []string{"a/x", "b/x", "c/x", "a/x", "b/x", "c/x"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestNewBalancedHandler_NoTargets(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("should have panicked")
+		}
+	}()
+	proxy.NewBalancedHandler(nil)
+}
+
+func TestLeastConnections(t *testing.T) {
+	t.Parallel()
+
+	a, b := mustParse(t, "http://a"), mustParse(t, "http://b")
+	bal := proxy.LeastConnections([]*url.URL{a, b})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	t1, done1 := bal.Next(req)
+	t2, done2 := bal.Next(req)
+	if t1 == t2 {
+		t.Fatalf("got %v twice; want both targets", t1)
+	}
+
+	// Finishing the first request makes its target the least loaded.
+	done1()
+	for range 3 {
+		got, done := bal.Next(req)
+		if got != t1 {
+			t.Errorf("got %v; want %v", got, t1)
+		}
+		done()
+	}
+	done2()
+}
+
+func TestRandom(t *testing.T) {
+	t.Parallel()
+
+	targets := []*url.URL{mustParse(t, "http://a"), mustParse(t, "http://b")}
+	bal := proxy.Random(targets)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	for range 10 {
+		got, done := bal.Next(req)
+		if !slices.Contains(targets, got) {
+			t.Errorf("got %v; want one of %v", got, targets)
+		}
+		done()
+	}
+}
+
+func TestWithBalancer(t *testing.T) {
+	t.Parallel()
+
+	var picked int
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+	))
+	defer srv.Close()
+	target := mustParse(t, srv.URL)
+
+	h := proxy.NewBalancedHandler(
+		[]*url.URL{target},
+		proxy.WithBalancer(func(targets []*url.URL) proxy.Balancer {
+			return balancerFunc(func(*http.Request) (*url.URL, func()) {
+				picked++
+				return targets[0], func() {}
+			})
+		}),
+	)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got, want := rec.Code, http.StatusNoContent; got != want {
+		t.Errorf("status code: got %d; want %d", got, want)
+	}
+	if got, want := picked, 1; got != want {
+		t.Errorf("picks: got %d; want %d", got, want)
+	}
+}
+
+// balancerFunc adapts a function to the proxy.Balancer interface.
+type balancerFunc func(*http.Request) (*url.URL, func())
+
+func (f balancerFunc) Next(r *http.Request) (*url.URL, func()) { return f(r) }
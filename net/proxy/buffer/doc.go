@@ -31,7 +31,7 @@
 //	pool := buffer.NewPool(32*1024, 1024*1024)
 //
 //	proxy := &httputil.ReverseProxy{
-//		Director:   director,
+//		Rewrite:    rewrite,
 //		BufferPool: pool,
 //	}
 package buffer
@@ -33,4 +33,25 @@
 //	)
 //
 //	http.ListenAndServe(":8080", proxyHandler)
+//
+// # Load Balancing
+//
+// To spread requests across a pool of upstreams, use [NewBalancedHandler]
+// and pick a strategy via [WithBalancer]:
+//
+//	proxyHandler := proxy.NewBalancedHandler(
+//	    []*url.URL{backend1, backend2, backend3},
+//	    proxy.WithBalancer(proxy.LeastConnections),
+//	    proxy.WithHealthCheck(proxy.HealthCheck{Cooldown: 30 * time.Second}),
+//	)
+//
+// With [WithHealthCheck], upstreams that repeatedly fail are routed around
+// for a cooldown period, and requests that fail to connect are retried on
+// another upstream.
+//
+// # WebSockets
+//
+// Protocol upgrades, such as WebSocket handshakes, pass through the proxy
+// without extra configuration. [WithWebSocketBufferSize] tunes the connection
+// buffers that carry the upgraded traffic.
 package proxy
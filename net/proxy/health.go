@@ -0,0 +1,121 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultFailureThreshold is the default number of consecutive failures
+	// after which an upstream is quarantined.
+	DefaultFailureThreshold = 3
+	// DefaultCooldown is the default time an upstream stays quarantined.
+	DefaultCooldown = 10 * time.Second
+)
+
+// HealthCheck configures the passive health checks of a handler created with
+// [NewBalancedHandler] (see [WithHealthCheck]).
+//
+// Health is judged from live traffic alone: a connection error or a 5xx
+// response counts as a failure of the upstream that produced it, and any other
+// response resets its count. An upstream that fails Threshold times in a row
+// is skipped for the duration of the Cooldown. Afterwards, it receives traffic
+// again, but a single further failure quarantines it anew, until a successful
+// response proves it has recovered.
+type HealthCheck struct {
+	// Threshold is the number of consecutive failures after which an
+	// upstream is quarantined. Nonpositive values select
+	// [DefaultFailureThreshold].
+	Threshold int
+	// Cooldown is how long a quarantined upstream is skipped. Nonpositive
+	// values select [DefaultCooldown].
+	Cooldown time.Duration
+}
+
+// monitor tracks the health of the upstream targets. It is safe for
+// concurrent use.
+type monitor struct {
+	threshold int
+	cooldown  time.Duration
+	// mu guards the states.
+	mu sync.Mutex
+	// states holds the health of each known target.
+	states map[*url.URL]*upstream
+}
+
+// upstream is the health state of a single target.
+type upstream struct {
+	// failures counts the consecutive failures.
+	failures int
+	// until is the end of the current quarantine, if any.
+	until time.Time
+}
+
+// newMonitor creates a monitor for the given targets, applying the defaults
+// to the zero fields of hc.
+func newMonitor(hc HealthCheck, targets []*url.URL) *monitor {
+	m := &monitor{
+		threshold: hc.Threshold,
+		cooldown:  hc.Cooldown,
+		states:    make(map[*url.URL]*upstream, len(targets)),
+	}
+	if m.threshold <= 0 {
+		m.threshold = DefaultFailureThreshold
+	}
+	if m.cooldown <= 0 {
+		m.cooldown = DefaultCooldown
+	}
+	for _, t := range targets {
+		m.states[t] = &upstream{}
+	}
+	return m
+}
+
+// healthy reports whether the target may receive traffic. Targets not known
+// to the monitor, such as ones made up by a custom [Balancer], always do.
+func (m *monitor) healthy(target *url.URL) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.states[target]
+	return !ok || !time.Now().Before(s.until)
+}
+
+// fail records a failure of the target and quarantines it once the threshold
+// is reached.
+func (m *monitor) fail(target *url.URL) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.states[target]
+	if !ok {
+		return
+	}
+	s.failures++
+	if s.failures >= m.threshold {
+		s.until = time.Now().Add(m.cooldown)
+	}
+}
+
+// succeed records a successful response from the target, clearing its
+// failure count.
+func (m *monitor) succeed(target *url.URL) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.states[target]; ok {
+		s.failures = 0
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/deep-rent/nexus/net/proxy"
+)
+
+// upstream starts a server that answers with the given status and name, and
+// counts the requests it receives.
+func upstream(t *testing.T, status int, name string) (*url.URL, *atomic.Int64) {
+	t.Helper()
+	var hits atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			hits.Add(1)
+			w.WriteHeader(status)
+			_, _ = io.WriteString(w, name)
+		},
+	))
+	t.Cleanup(srv.Close)
+	return mustParse(t, srv.URL), &hits
+}
+
+// unreachable returns the URL of a server that is no longer listening.
+func unreachable(t *testing.T) *url.URL {
+	t.Helper()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	u := mustParse(t, srv.URL)
+	srv.Close()
+	return u
+}
+
+// send passes a request through h and returns the status code and body.
+func send(t *testing.T, h http.Handler, method, body string) (int, string) {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	req, err := http.NewRequestWithContext(
+		t.Context(), method, srv.URL, strings.NewReader(body),
+	)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if body == "" {
+		req.Body = http.NoBody
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	return res.StatusCode, string(b)
+}
+
+func TestWithHealthCheck_Failover(t *testing.T) {
+	t.Parallel()
+
+	live, _ := upstream(t, http.StatusOK, "live")
+	h := proxy.NewBalancedHandler(
+		[]*url.URL{unreachable(t), live},
+		proxy.WithHealthCheck(proxy.HealthCheck{}),
+	)
+
+	for range 4 {
+		code, body := send(t, h, http.MethodGet, "")
+		if got, want := code, http.StatusOK; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+		if got, want := body, "live"; got != want {
+			t.Errorf("body: got %q; want %q", got, want)
+		}
+	}
+}
+
+func TestWithHealthCheck_NoRetryWithBody(t *testing.T) {
+	t.Parallel()
+
+	live, hits := upstream(t, http.StatusOK, "live")
+	h := proxy.NewBalancedHandler(
+		[]*url.URL{unreachable(t), live},
+		proxy.WithHealthCheck(proxy.HealthCheck{}),
+	)
+
+	code, _ := send(t, h, http.MethodPost, "payload")
+	if got, want := code, http.StatusBadGateway; got != want {
+		t.Errorf("status code: got %d; want %d", got, want)
+	}
+	if got := hits.Load(); got != 0 {
+		t.Errorf("upstream hits: got %d; want 0", got)
+	}
+}
+
+func TestWithHealthCheck_Quarantine(t *testing.T) {
+	t.Parallel()
+
+	broken, brokenHits := upstream(t, http.StatusInternalServerError, "broken")
+	live, _ := upstream(t, http.StatusOK, "live")
+	h := proxy.NewBalancedHandler(
+		[]*url.URL{broken, live},
+		proxy.WithHealthCheck(proxy.HealthCheck{
+			Threshold: 1,
+			Cooldown:  time.Minute,
+		}),
+	)
+
+	// The 5xx response is passed on, but quarantines the upstream.
+	code, _ := send(t, h, http.MethodGet, "")
+	if got, want := code, http.StatusInternalServerError; got != want {
+		t.Errorf("status code: got %d; want %d", got, want)
+	}
+
+	for range 4 {
+		code, _ := send(t, h, http.MethodGet, "")
+		if got, want := code, http.StatusOK; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+	}
+	if got, want := brokenHits.Load(), int64(1); got != want {
+		t.Errorf("upstream hits: got %d; want %d", got, want)
+	}
+}
+
+func TestWithHealthCheck_AllUnhealthy(t *testing.T) {
+	t.Parallel()
+
+	h := proxy.NewBalancedHandler(
+		[]*url.URL{unreachable(t), unreachable(t)},
+		proxy.WithHealthCheck(proxy.HealthCheck{Threshold: 1}),
+	)
+
+	for range 3 {
+		code, _ := send(t, h, http.MethodGet, "")
+		if got, want := code, http.StatusBadGateway; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+	}
+}
+
+func TestWithHealthCheck_ClientDeadline(t *testing.T) {
+	t.Parallel()
+
+	slow, entered, release := blocking(t)
+	live, liveHits := upstream(t, http.StatusOK, "live")
+	h := proxy.NewBalancedHandler(
+		[]*url.URL{slow, live},
+		proxy.WithHealthCheck(proxy.HealthCheck{
+			Threshold: 1,
+			Cooldown:  time.Minute,
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequestWithContext(
+		ctx, http.MethodGet, "/", nil,
+	))
+
+	if got, want := rec.Code, http.StatusGatewayTimeout; got != want {
+		t.Errorf("status code: got %d; want %d", got, want)
+	}
+	if got := liveHits.Load(); got != 0 {
+		t.Errorf("retries: got %d; want 0", got)
+	}
+
+	// The client's deadline must not have quarantined the slow upstream.
+	release()
+	for range 2 {
+		code, _ := send(t, h, http.MethodGet, "")
+		if got, want := code, http.StatusOK; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+	}
+	if got, want := len(entered), 2; got != want {
+		t.Errorf("slow upstream hits: got %d; want %d", got, want)
+	}
+}
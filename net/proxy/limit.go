@@ -0,0 +1,90 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrSaturated is passed to the [ErrorHandler] when a request is turned away
+// because its upstream already serves the maximum number of concurrent
+// requests (see [WithMaxConcurrency]). The default error handler answers it
+// with 503 Service Unavailable.
+var ErrSaturated = errors.New("upstream saturated")
+
+// limiter caps the number of requests in flight to an upstream.
+type limiter struct {
+	// sem holds a token for each request in flight.
+	sem chan struct{}
+	// wait is how long to wait for a free slot; 0 fails fast.
+	wait time.Duration
+}
+
+// newLimiter creates a limiter that admits up to n concurrent requests.
+func newLimiter(n int, wait time.Duration) *limiter {
+	return &limiter{sem: make(chan struct{}, n), wait: wait}
+}
+
+// acquire claims a slot, waiting for one to become free for at most the
+// configured duration. It returns [ErrSaturated] if none does, or the context
+// error if ctx is done first. A successful call must be paired with release.
+func (l *limiter) acquire(ctx context.Context) error {
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	default:
+	}
+	if l.wait <= 0 {
+		return ErrSaturated
+	}
+
+	t := time.NewTimer(l.wait)
+	defer t.Stop()
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	case <-t.C:
+		return ErrSaturated
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot claimed by acquire.
+func (l *limiter) release() {
+	<-l.sem
+}
+
+// limited is a reverse proxy handler guarded by a [limiter].
+type limited struct {
+	*limiter
+	// next is the guarded handler.
+	next http.Handler
+	// handle reports requests that are turned away.
+	handle ErrorHandler
+}
+
+// ServeHTTP implements [http.Handler].
+func (l *limited) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := l.acquire(r.Context()); err != nil {
+		l.handle(w, r, err)
+		return
+	}
+	defer l.release()
+	l.next.ServeHTTP(w, r)
+}
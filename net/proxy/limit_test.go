@@ -0,0 +1,131 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/deep-rent/nexus/net/proxy"
+)
+
+// blocking starts an upstream that signals each arriving request on entered
+// and holds it until release is closed.
+func blocking(t *testing.T) (
+	u *url.URL,
+	entered chan struct{},
+	release func(),
+) {
+	t.Helper()
+	entered = make(chan struct{}, 8)
+	gate := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			entered <- struct{}{}
+			<-gate
+			w.WriteHeader(http.StatusOK)
+		},
+	))
+	var closed bool
+	release = func() {
+		if !closed {
+			closed = true
+			close(gate)
+		}
+	}
+	t.Cleanup(func() {
+		release()
+		srv.Close()
+	})
+	return mustParse(t, srv.URL), entered, release
+}
+
+// serve passes a GET request through h in the background and delivers the
+// status code.
+func serve(h http.Handler) <-chan int {
+	done := make(chan int, 1)
+	go func() {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		done <- rec.Code
+	}()
+	return done
+}
+
+func TestWithMaxConcurrency(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fails fast when saturated", func(t *testing.T) {
+		t.Parallel()
+		u, entered, release := blocking(t)
+		h := proxy.NewHandler(u, proxy.WithMaxConcurrency(1))
+
+		first := serve(h)
+		<-entered
+		if got, want := <-serve(h), http.StatusServiceUnavailable; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+		release()
+		if got, want := <-first, http.StatusOK; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+	})
+
+	t.Run("waits for a free slot", func(t *testing.T) {
+		t.Parallel()
+		u, entered, release := blocking(t)
+		h := proxy.NewHandler(u,
+			proxy.WithMaxConcurrency(1),
+			proxy.WithConcurrencyWait(time.Minute),
+		)
+
+		first := serve(h)
+		<-entered
+		second := serve(h)
+		release()
+		for _, done := range []<-chan int{first, second} {
+			if got, want := <-done, http.StatusOK; got != want {
+				t.Errorf("status code: got %d; want %d", got, want)
+			}
+		}
+	})
+
+	t.Run("caps each upstream separately", func(t *testing.T) {
+		t.Parallel()
+		u1, entered1, release1 := blocking(t)
+		u2, entered2, release2 := blocking(t)
+		h := proxy.NewBalancedHandler(
+			[]*url.URL{u1, u2},
+			proxy.WithMaxConcurrency(1),
+		)
+
+		first, second := serve(h), serve(h)
+		<-entered1
+		<-entered2
+		if got, want := <-serve(h), http.StatusServiceUnavailable; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+		release1()
+		release2()
+		for _, done := range []<-chan int{first, second} {
+			if got, want := <-done, http.StatusOK; got != want {
+				t.Errorf("status code: got %d; want %d", got, want)
+			}
+		}
+	})
+}
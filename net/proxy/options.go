@@ -44,6 +44,20 @@ type handlerConfig struct {
 	newRewrite RewriteFactory
 	// newErrorHandler is the factory for creating the error handling function.
 	newErrorHandler ErrorHandlerFactory
+	// newBalancer is the factory for creating the load balancing strategy.
+	newBalancer BalancerFactory
+	// health configures passive health checks; nil disables them.
+	health *HealthCheck
+	// modifyResponse holds the response modifiers, applied in order.
+	modifyResponse []ModifyResponseFunc
+	// maxConcurrency caps the requests in flight per upstream; 0 means
+	// unlimited.
+	maxConcurrency int
+	// concurrencyWait is how long a request waits for a free slot.
+	concurrencyWait time.Duration
+	// ioBufferSize is the size of the transport's connection buffers; 0 keeps
+	// the transport's own setting.
+	ioBufferSize int
 	// logger is the structured logger for error reporting.
 	logger *log.Logger
 }
@@ -100,6 +114,56 @@ func WithMaxBufferSize(n int) HandlerOption {
 	}
 }
 
+// WithWebSocketBufferSize sets the size of the read and write buffers the
+// transport uses for each upstream connection.
+//
+// The proxy passes protocol upgrades such as WebSocket through transparently:
+// once the upstream answers with 101 Switching Protocols, the client and
+// upstream connections are spliced together, bypassing the buffer pool and
+// flush interval. The transport's connection buffers, 4 KiB by default, then
+// carry all traffic read from the upstream, so raising their size helps with
+// large or frequent WebSocket messages. Timeouts for upgraded connections are
+// governed by the [http.Server] deadlines, which should be generous enough for
+// long-lived sessions.
+//
+// The sizes are applied to a clone of the configured transport, which
+// therefore does not share its connection pool with other users. Nonpositive
+// values are ignored.
+func WithWebSocketBufferSize(n int) HandlerOption {
+	return func(cfg *handlerConfig) {
+		if n > 0 {
+			cfg.ioBufferSize = n
+		}
+	}
+}
+
+// WithMaxConcurrency caps the number of requests in flight to each upstream
+// at n, protecting fragile backends from overload.
+//
+// A request that finds its upstream saturated waits for a free slot for as
+// long as configured via [WithConcurrencyWait], failing fast by default. If
+// none becomes free, the [ErrorHandler] is called with [ErrSaturated], which
+// the default handler answers with 503 Service Unavailable. Handlers created
+// with [NewBalancedHandler] keep a separate cap for each target. Nonpositive
+// values are ignored, leaving the concurrency unlimited.
+func WithMaxConcurrency(n int) HandlerOption {
+	return func(cfg *handlerConfig) {
+		if n > 0 {
+			cfg.maxConcurrency = n
+		}
+	}
+}
+
+// WithConcurrencyWait sets how long a request waits for a saturated upstream
+// to free a slot before it is turned away (see [WithMaxConcurrency]).
+//
+// By default, or if d is nonpositive, requests are turned away at once.
+func WithConcurrencyWait(d time.Duration) HandlerOption {
+	return func(cfg *handlerConfig) {
+		cfg.concurrencyWait = d
+	}
+}
+
 // WithRewrite provides a custom [RewriteFactory] for the proxy.
 //
 // If nil is given, this option is ignored. By default, [NewRewrite] is used.
@@ -123,6 +187,43 @@ func WithErrorHandler(f ErrorHandlerFactory) HandlerOption {
 	}
 }
 
+// WithModifyResponse adds a [ModifyResponseFunc] that can inspect and change
+// upstream responses, for instance to strip headers such as Server.
+//
+// The option may be given several times; the functions then run in order,
+// and the first error aborts the chain and is routed through the
+// [ErrorHandler]. If nil is given, this option is ignored.
+func WithModifyResponse(f ModifyResponseFunc) HandlerOption {
+	return func(cfg *handlerConfig) {
+		if f != nil {
+			cfg.modifyResponse = append(cfg.modifyResponse, f)
+		}
+	}
+}
+
+// WithLocationRewrite rewrites the Location and Content-Location headers of
+// upstream responses that start with the prefix from, replacing it with to.
+// This keeps redirects issued by the upstream pointing at the public host:
+//
+//	proxy.WithLocationRewrite(
+//	    "http://backend.internal:8080",
+//	    "https://api.example.com",
+//	)
+//
+// The prefix only matches whole hosts and path segments, and other values are
+// left alone. It runs as a response modifier (see [WithModifyResponse]). If
+// from is empty, this option is ignored.
+func WithLocationRewrite(from, to string) HandlerOption {
+	return func(cfg *handlerConfig) {
+		if from != "" {
+			cfg.modifyResponse = append(
+				cfg.modifyResponse,
+				rewriteLocation(from, to),
+			)
+		}
+	}
+}
+
 // WithLogger sets the [log.Logger] to be used by the proxy's
 // [ErrorHandler].
 //
@@ -136,3 +237,27 @@ func WithLogger(logger *log.Logger) HandlerOption {
 		}
 	}
 }
+
+// WithBalancer provides a custom [BalancerFactory] for handlers created with
+// [NewBalancedHandler]. Besides the default [RoundRobin], the package offers
+// [LeastConnections] and [Random].
+//
+// If nil is given, this option is ignored. [NewHandler] has a single target
+// and ignores this option as well.
+func WithBalancer(f BalancerFactory) HandlerOption {
+	return func(cfg *handlerConfig) {
+		if f != nil {
+			cfg.newBalancer = f
+		}
+	}
+}
+
+// WithHealthCheck enables passive health checks for handlers created with
+// [NewBalancedHandler], as configured by hc. Upstreams that keep failing are
+// quarantined and routed around, and requests that fail to connect are retried
+// elsewhere. [NewHandler] has a single target and ignores this option.
+func WithHealthCheck(hc HealthCheck) HandlerOption {
+	return func(cfg *handlerConfig) {
+		cfg.health = &hc
+	}
+}
@@ -20,9 +20,12 @@ package proxy
 import (
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/deep-rent/nexus/net/proxy/buffer"
 	"github.com/deep-rent/nexus/sys/log"
@@ -36,6 +39,216 @@ type Handler = http.Handler
 // The behavior of the proxy can be customized through the given options. It
 // avoids the deprecated Director hook in favor of the modern Rewrite API.
 func NewHandler(target *url.URL, opts ...HandlerOption) Handler {
+	cfg := newConfig(opts)
+	h := newReverseProxy(&cfg, func(*http.Request) *url.URL {
+		return target
+	})
+	if cfg.maxConcurrency <= 0 {
+		return h
+	}
+	return &limited{
+		limiter: newLimiter(cfg.maxConcurrency, cfg.concurrencyWait),
+		next:    h,
+		handle:  h.ErrorHandler,
+	}
+}
+
+// NewBalancedHandler creates a new reverse proxy handler that spreads requests
+// across the target URLs.
+//
+// Each request is routed to the target chosen by the configured [Balancer],
+// which defaults to [RoundRobin] and can be replaced via [WithBalancer]. The
+// chosen target is applied by the default rewrite, so a custom
+// [RewriteFactory] that keeps calling the original rewrite needs no changes.
+// Otherwise, the handler behaves like one created by [NewHandler]. It panics
+// if no targets are given.
+//
+// With [WithHealthCheck], upstreams that keep failing are taken out of
+// rotation for a while. If no healthy upstream is left, the balancer's choice
+// is used regardless. A request that fails because no connection to its
+// upstream could be established is retried on another upstream, provided its
+// body can be sent again: that is, it has no body or sets
+// [http.Request.GetBody]. Other failures, including 5xx responses, are passed
+// on to the client, as the upstream may already have acted on the request.
+// Requests canceled or timed out on the client side count against no
+// upstream.
+func NewBalancedHandler(targets []*url.URL, opts ...HandlerOption) Handler {
+	if len(targets) == 0 {
+		panic("at least one target is required")
+	}
+	cfg := newConfig(opts)
+	b := &balanced{
+		balancer: cfg.newBalancer(targets),
+		size:     len(targets),
+	}
+	b.proxy = newReverseProxy(&cfg, func(r *http.Request) *url.URL {
+		a, _ := r.Context().Value(attemptKey{}).(*attempt)
+		return a.target
+	})
+	b.handle = b.proxy.ErrorHandler
+	if n := cfg.maxConcurrency; n > 0 {
+		b.limits = make(map[*url.URL]*limiter, len(targets))
+		for _, t := range targets {
+			b.limits[t] = newLimiter(n, cfg.concurrencyWait)
+		}
+	}
+	if cfg.health != nil {
+		b.monitor = newMonitor(*cfg.health, targets)
+		b.guard(b.handle)
+	}
+	return b
+}
+
+// attemptKey is the context key under which a [balanced] handler passes the
+// current attempt to the proxy hooks.
+type attemptKey struct{}
+
+// attempt describes a single try to forward a request upstream.
+type attempt struct {
+	// target is the chosen upstream.
+	target *url.URL
+	// retry tells whether the request may be retried if this attempt fails.
+	retry bool
+	// failed is set if this attempt failed and should be retried.
+	failed bool
+	// responded is set once the upstream has answered.
+	responded bool
+}
+
+// balanced is a reverse proxy handler that balances requests across several
+// upstreams.
+type balanced struct {
+	proxy    *httputil.ReverseProxy
+	balancer Balancer
+	// size is the number of targets.
+	size int
+	// monitor tracks upstream health; it is nil if health checks are off.
+	monitor *monitor
+	// limits caps the requests in flight per target; it is nil if
+	// unlimited.
+	limits map[*url.URL]*limiter
+	// handle is the configured error handler, which reports failures
+	// without involving the health checks.
+	handle ErrorHandler
+}
+
+// guard hooks the health monitor into the proxy. Failed attempts are recorded
+// and, if possible, marked for a retry instead of being passed on to the
+// original error handler.
+func (b *balanced) guard(handle ErrorHandler) {
+	b.proxy.ErrorHandler = func(
+		w http.ResponseWriter,
+		r *http.Request,
+		err error,
+	) {
+		a, _ := r.Context().Value(attemptKey{}).(*attempt)
+		// A request that was canceled or ran out of time on the client side
+		// says nothing about the upstream, and neither does a response
+		// rejected by a modifier, which has already been recorded.
+		if a != nil && !a.responded && r.Context().Err() == nil {
+			b.monitor.fail(a.target)
+			// Only a request that never reached the upstream is safe to send
+			// elsewhere.
+			if a.retry && undelivered(err) {
+				a.failed = true
+				return
+			}
+		}
+		handle(w, r, err)
+	}
+	modify := b.proxy.ModifyResponse
+	b.proxy.ModifyResponse = func(res *http.Response) error {
+		a, _ := res.Request.Context().Value(attemptKey{}).(*attempt)
+		if a != nil {
+			a.responded = true
+			if res.StatusCode >= http.StatusInternalServerError {
+				b.monitor.fail(a.target)
+			} else {
+				b.monitor.succeed(a.target)
+			}
+		}
+		if modify == nil {
+			return nil
+		}
+		return modify(res)
+	}
+}
+
+// undelivered reports whether err shows that a connection to the upstream
+// could not be established, so that the request was never written.
+func undelivered(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// ServeHTTP implements [http.Handler].
+func (b *balanced) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	replayable := r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
+	var tried []*url.URL
+	for {
+		a := &attempt{retry: b.monitor != nil && replayable}
+		if !b.forward(w, r, a, tried) {
+			return
+		}
+		tried = append(tried, a.target)
+	}
+}
+
+// forward makes a single attempt, avoiding the targets already tried where
+// possible. It reports whether the attempt failed and should be retried.
+func (b *balanced) forward(
+	w http.ResponseWriter,
+	r *http.Request,
+	a *attempt,
+	tried []*url.URL,
+) bool {
+	var done func()
+	a.target, done = b.pick(r, tried)
+	defer done()
+
+	if l := b.limits[a.target]; l != nil {
+		if err := l.acquire(r.Context()); err != nil {
+			b.handle(w, r, err)
+			return false
+		}
+		defer l.release()
+	}
+
+	// Retry only while untried upstreams remain.
+	a.retry = a.retry && len(tried) < b.size-1
+
+	out := r.WithContext(context.WithValue(r.Context(), attemptKey{}, a))
+	if len(tried) != 0 && r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			b.proxy.ErrorHandler(w, out, err)
+			return false
+		}
+		out.Body = body
+	}
+	b.proxy.ServeHTTP(w, out)
+	return a.failed
+}
+
+// pick asks the balancer for a target, skipping unhealthy and already tried
+// ones for as long as the balancer offers alternatives.
+func (b *balanced) pick(r *http.Request, tried []*url.URL) (*url.URL, func()) {
+	target, done := b.balancer.Next(r)
+	if b.monitor == nil {
+		return target, done
+	}
+	for range b.size - 1 {
+		if b.monitor.healthy(target) && !slices.Contains(tried, target) {
+			break
+		}
+		done()
+		target, done = b.balancer.Next(r)
+	}
+	return target, done
+}
+
+// newConfig applies the options on top of the defaults.
+func newConfig(opts []HandlerOption) handlerConfig {
 	cfg := handlerConfig{
 		transport:       http.DefaultTransport.(*http.Transport).Clone(),
 		flushInterval:   0,
@@ -43,6 +256,7 @@ func NewHandler(target *url.URL, opts ...HandlerOption) Handler {
 		maxBufferSize:   DefaultMaxBufferSize,
 		newRewrite:      NewRewrite,
 		newErrorHandler: NewErrorHandler,
+		newBalancer:     RoundRobin,
 		logger:          log.Discard(),
 	}
 	for _, opt := range opts {
@@ -52,7 +266,20 @@ func NewHandler(target *url.URL, opts ...HandlerOption) Handler {
 	if cfg.minBufferSize > cfg.maxBufferSize {
 		cfg.minBufferSize = cfg.maxBufferSize
 	}
+	if n := cfg.ioBufferSize; n > 0 {
+		cfg.transport = cfg.transport.Clone()
+		cfg.transport.ReadBufferSize = n
+		cfg.transport.WriteBufferSize = n
+	}
+	return cfg
+}
 
+// newReverseProxy builds the reverse proxy described by cfg, routing each
+// request to the URL returned by target.
+func newReverseProxy(
+	cfg *handlerConfig,
+	target func(*http.Request) *url.URL,
+) *httputil.ReverseProxy {
 	// Construct ReverseProxy directly to avoid the deprecated Director hook
 	// set by NewSingleHostReverseProxy.
 	h := &httputil.ReverseProxy{
@@ -64,14 +291,54 @@ func NewHandler(target *url.URL, opts ...HandlerOption) Handler {
 
 	defaultRewrite := func(pr *httputil.ProxyRequest) {
 		pr.SetXForwarded()
-		pr.SetURL(target)
+		pr.SetURL(target(pr.In))
 	}
 
 	h.Rewrite = cfg.newRewrite(defaultRewrite)
 
+	if mods := cfg.modifyResponse; len(mods) != 0 {
+		h.ModifyResponse = func(res *http.Response) error {
+			for _, modify := range mods {
+				if err := modify(res); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
 	return h
 }
 
+// ModifyResponseFunc defines a function to modify responses before they are
+// passed back to the client. If it returns an error, the response is discarded
+// and the configured [ErrorHandler] is called instead.
+//
+// The signature matches [httputil.ReverseProxy.ModifyResponse].
+type ModifyResponseFunc = func(*http.Response) error
+
+// rewriteLocation returns a [ModifyResponseFunc] that replaces the prefix from
+// with to in the Location and Content-Location headers. Unless it ends with a
+// slash, the prefix must be followed by the end of the value or by a path,
+// query, or fragment, so that "http://backend" does not match
+// "http://backend2".
+func rewriteLocation(from, to string) ModifyResponseFunc {
+	return func(res *http.Response) error {
+		for _, key := range []string{"Location", "Content-Location"} {
+			rest, ok := strings.CutPrefix(res.Header.Get(key), from)
+			if !ok {
+				continue
+			}
+			if rest != "" && !strings.HasSuffix(from, "/") &&
+				!strings.ContainsRune("/?#", rune(rest[0])) {
+				continue
+			}
+			res.Header.Set(key, to+rest)
+		}
+		return nil
+	}
+}
+
 // RewriteFunc defines a function to modify requests before they go upstream.
 //
 // The signature matches [httputil.ReverseProxy.Rewrite].
@@ -106,6 +373,8 @@ type ErrorHandlerFactory = func(*log.Logger) ErrorHandler
 //
 // It creates an error handler that logs upstream errors and maps them to
 // appropriate HTTP status codes, while silencing client-initiated disconnects.
+// Requests turned away with [ErrSaturated] are answered with 503 Service
+// Unavailable.
 func NewErrorHandler(logger *log.Logger) ErrorHandler {
 	return func(w http.ResponseWriter, r *http.Request, err error) {
 		if errors.Is(err, context.Canceled) {
@@ -126,6 +395,14 @@ func NewErrorHandler(logger *log.Logger) ErrorHandler {
 				log.String("method", method),
 				log.String("uri", uri),
 			)
+		} else if errors.Is(err, ErrSaturated) {
+			status = http.StatusServiceUnavailable
+			logger.Warn(
+				r.Context(),
+				"Upstream saturated",
+				log.String("method", method),
+				log.String("uri", uri),
+			)
 		} else {
 			logger.Error(
 				r.Context(),
@@ -289,3 +289,108 @@ func TestWithErrorHandler_Functional_CustomHandler(t *testing.T) {
 		t.Errorf("status code: got %d; want %d", got, want)
 	}
 }
+
+func TestWithModifyResponse(t *testing.T) {
+	t.Parallel()
+
+	backend := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Server", "backend/1.0")
+			_, _ = w.Write([]byte("ok"))
+		},
+	))
+	t.Cleanup(backend.Close)
+	u := mustParse(t, backend.URL)
+
+	t.Run("strips headers", func(t *testing.T) {
+		t.Parallel()
+		h := proxy.NewHandler(u,
+			proxy.WithModifyResponse(func(res *http.Response) error {
+				res.Header.Del("Server")
+				return nil
+			}),
+		)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if got := rec.Header().Get("Server"); got != "" {
+			t.Errorf("server header: got %q; want empty", got)
+		}
+		if got, want := rec.Body.String(), "ok"; got != want {
+			t.Errorf("body: got %q; want %q", got, want)
+		}
+	})
+
+	t.Run("routes errors through the error handler", func(t *testing.T) {
+		t.Parallel()
+		errReject := errors.New("rejected")
+		var got error
+		h := proxy.NewHandler(u,
+			proxy.WithModifyResponse(func(*http.Response) error {
+				return errReject
+			}),
+			proxy.WithErrorHandler(func(*log.Logger) proxy.ErrorHandler {
+				return func(w http.ResponseWriter, _ *http.Request, err error) {
+					got = err
+					w.WriteHeader(http.StatusTeapot)
+				}
+			}),
+		)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if !errors.Is(got, errReject) {
+			t.Errorf("got %v; want %v", got, errReject)
+		}
+		if got, want := rec.Code, http.StatusTeapot; got != want {
+			t.Errorf("status code: got %d; want %d", got, want)
+		}
+	})
+}
+
+func TestWithLocationRewrite(t *testing.T) {
+	t.Parallel()
+
+	backend := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", r.URL.Query().Get("to"))
+			w.Header().Set("Content-Location", r.URL.Query().Get("to"))
+			w.WriteHeader(http.StatusFound)
+		},
+	))
+	t.Cleanup(backend.Close)
+	location := backend.URL
+
+	h := proxy.NewHandler(
+		mustParse(t, backend.URL),
+		proxy.WithLocationRewrite(location, "https://api.example.com"),
+	)
+
+	tests := []struct {
+		name string
+		to   string
+		want string
+	}{
+		{"path", location + "/a?b=c", "https://api.example.com/a?b=c"},
+		{"bare host", location, "https://api.example.com"},
+		{"other host", "https://elsewhere.com/x", "https://elsewhere.com/x"},
+		{"longer host", location + "0/x", location + "0/x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			target := "/?to=" + url.QueryEscape(tt.to)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+			for _, key := range []string{"Location", "Content-Location"} {
+				if got := rec.Header().Get(key); got != tt.want {
+					t.Errorf("%s: got %q; want %q", key, got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deep-rent/nexus/net/proxy"
+)
+
+// echo is an upstream that accepts WebSocket upgrades and echoes every byte
+// received afterwards. Framing is irrelevant to the proxy, so it is skipped.
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		w.WriteHeader(http.StatusUpgradeRequired)
+		return
+	}
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n\r\n")
+	if rw.Flush() != nil {
+		return
+	}
+	_, _ = io.Copy(conn, rw)
+})
+
+func TestWithWebSocketBufferSize(t *testing.T) {
+	t.Parallel()
+
+	backend := httptest.NewServer(echo)
+	defer backend.Close()
+
+	h := proxy.NewHandler(mustParse(t, backend.URL),
+		proxy.WithWebSocketBufferSize(64<<10),
+		proxy.WithFlushInterval(-1),
+		proxy.WithMinBufferSize(1024),
+	)
+
+	rp, ok := h.(*httputil.ReverseProxy)
+	if !ok {
+		t.Fatalf("handler type: got %T; want *httputil.ReverseProxy", h)
+	}
+	tr, ok := rp.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport type: got %T; want *http.Transport", rp.Transport)
+	}
+	if got, want := tr.ReadBufferSize, 64<<10; got != want {
+		t.Errorf("read buffer size: got %d; want %d", got, want)
+	}
+	if got, want := tr.WriteBufferSize, 64<<10; got != want {
+		t.Errorf("write buffer size: got %d; want %d", got, want)
+	}
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	_, err = io.WriteString(conn, "GET /ws HTTP/1.1\r\n"+
+		"Host: "+srv.Listener.Addr().String()+"\r\n"+
+		"Upgrade: websocket\r\n"+
+		"Connection: Upgrade\r\n\r\n")
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	br := bufio.NewReader(conn)
+	res, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if got, want := res.StatusCode, http.StatusSwitchingProtocols; got != want {
+		t.Fatalf("status code: got %d; want %d", got, want)
+	}
+	if got, want := res.Header.Get("Upgrade"), "websocket"; got != want {
+		t.Errorf("upgrade header: got %q; want %q", got, want)
+	}
+
+	for _, msg := range []string{"hello", strings.Repeat("x", 100<<10)} {
+		if _, err := io.WriteString(conn, msg); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		buf := make([]byte, len(msg))
+		if _, err := io.ReadFull(br, buf); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		if string(buf) != msg {
+			t.Errorf("echo: mismatch for a message of %d bytes", len(msg))
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/deep-rent/nexus/std/clock"
+)
+
+// ErrCircuitOpen is returned by the transport instead of sending a request
+// while the circuit breaker configured through [WithCircuitBreaker] is open.
+var ErrCircuitOpen = errors.New("retry: circuit breaker is open")
+
+// breaker stops traffic to an upstream after a run of failed attempts. It is
+// shared by all requests passing through a transport.
+type breaker struct {
+	threshold int           // failures within the cooldown that open it
+	cooldown  time.Duration // time the circuit stays open
+	now       clock.Clock   // time source for the cooldown
+
+	mu       sync.Mutex // guards the fields below
+	failures int        // failed attempts in the current window
+	since    time.Time  // instant of the first failure in the window
+	open     bool       // whether the circuit is open or half-open
+	until    time.Time  // instant at which an open circuit half-opens
+	probe    time.Time  // instant at which the pending probe was admitted
+}
+
+// allow reports whether a request may be sent to the upstream. Once the
+// cooldown has elapsed, the circuit is half-open and admits a single probe,
+// whose outcome decides whether the circuit closes or opens again.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return true
+	}
+	now := b.now()
+	if now.Before(b.until) {
+		return false
+	}
+	// A probe that never reports back, for instance because the caller gave
+	// up on it, must not block the circuit forever, so it is replaced after
+	// another cooldown.
+	if !b.probe.IsZero() && now.Sub(b.probe) < b.cooldown {
+		return false
+	}
+	b.probe = now
+	return true
+}
+
+// record updates the breaker with the outcome of an attempt.
+func (b *breaker) record(a Attempt) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// An attempt the caller abandoned says nothing about the upstream.
+	if canceled(a) {
+		return
+	}
+
+	if !failed(a) {
+		b.open = false
+		b.failures = 0
+		b.probe = time.Time{}
+		return
+	}
+
+	now := b.now()
+	if b.open {
+		// The probe failed, so the circuit opens again.
+		b.until = now.Add(b.cooldown)
+		b.probe = time.Time{}
+		return
+	}
+
+	// Sporadic failures spread over a long time must not add up to an open
+	// circuit, so the count starts over once the window has passed.
+	if b.failures == 0 || now.Sub(b.since) >= b.cooldown {
+		b.failures = 0
+		b.since = now
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.open = true
+		b.until = now.Add(b.cooldown)
+		b.failures = 0
+		b.probe = time.Time{}
+	}
+}
+
+// failed reports whether an attempt counts against the circuit breaker.
+// Unlike [Attempt.Transient], this includes all network errors, such as a
+// refused connection, since these are precisely what a dead upstream
+// produces. Errors caused by the caller giving up are not counted.
+func failed(a Attempt) bool {
+	if a.Error != nil {
+		return !canceled(a)
+	}
+	return a.Temporary()
+}
+
+// canceled reports whether an attempt failed because the caller gave up.
+func canceled(a Attempt) bool {
+	return errors.Is(a.Error, context.Canceled) ||
+		errors.Is(a.Error, context.DeadlineExceeded)
+}
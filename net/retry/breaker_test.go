@@ -0,0 +1,306 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/deep-rent/nexus/net/retry"
+)
+
+// manual is a clock that only moves when advanced.
+type manual struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (m *manual) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+func (m *manual) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = m.now.Add(d)
+}
+
+// send issues a GET request through the given transport.
+func send(t *testing.T, tr http.RoundTripper) (*http.Response, error) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(
+		t.Context(), http.MethodGet, "http://example.com", nil,
+	)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	res, err := tr.RoundTrip(req)
+	if res != nil {
+		t.Cleanup(func() { _ = res.Body.Close() })
+	}
+	return res, err
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	clk := &manual{now: time.Date(2026, time.July, 20, 12, 0, 0, 0, time.UTC)}
+
+	var mu sync.Mutex
+	calls, status := 0, http.StatusServiceUnavailable
+	next := tripFunc(func(*http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return respond(status, newBody("body")), nil
+	})
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls
+	}
+
+	tr := retry.NewTransport(next,
+		retry.WithAttemptLimit(5),
+		retry.WithCircuitBreaker(3, time.Minute),
+		retry.WithClock(clk.Now),
+	)
+
+	// The third failure opens the circuit, which ends the retry loop early
+	// with the last response rather than an error.
+	res, err := send(t, tr)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if res.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status: got %d; want %d",
+			res.StatusCode, http.StatusServiceUnavailable)
+	}
+	if n := count(); n != 3 {
+		t.Errorf("calls: got %d; want 3", n)
+	}
+
+	// While open, requests fail fast without reaching the upstream.
+	if _, err := send(t, tr); !errors.Is(err, retry.ErrCircuitOpen) {
+		t.Errorf("got %v; want %v", err, retry.ErrCircuitOpen)
+	}
+	if n := count(); n != 3 {
+		t.Errorf("calls: got %d; want 3", n)
+	}
+
+	// After the cooldown, a single failure reopens the circuit.
+	clk.Advance(time.Minute)
+	if _, err := send(t, tr); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if n := count(); n != 4 {
+		t.Errorf("calls: got %d; want 4", n)
+	}
+	if _, err := send(t, tr); !errors.Is(err, retry.ErrCircuitOpen) {
+		t.Errorf("got %v; want %v", err, retry.ErrCircuitOpen)
+	}
+
+	// A success after the cooldown closes the circuit again.
+	clk.Advance(time.Minute)
+	mu.Lock()
+	status = http.StatusOK
+	mu.Unlock()
+	for range 3 {
+		if _, err := send(t, tr); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+	}
+	if n := count(); n != 7 {
+		t.Errorf("calls: got %d; want 7", n)
+	}
+}
+
+func TestWithCircuitBreaker_Window(t *testing.T) {
+	t.Parallel()
+
+	clk := &manual{now: time.Date(2026, time.July, 20, 12, 0, 0, 0, time.UTC)}
+
+	var calls int
+	tr := retry.NewTransport(
+		counter(http.StatusServiceUnavailable, &calls),
+		retry.WithAttemptLimit(1),
+		retry.WithCircuitBreaker(2, time.Minute),
+		retry.WithClock(clk.Now),
+	)
+
+	// Failures further apart than the cooldown do not add up, while the
+	// second failure within the window opens the circuit.
+	for _, d := range []time.Duration{0, time.Minute, 0} {
+		clk.Advance(d)
+		if _, err := send(t, tr); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+	}
+	if _, err := send(t, tr); !errors.Is(err, retry.ErrCircuitOpen) {
+		t.Errorf("got %v; want %v", err, retry.ErrCircuitOpen)
+	}
+	if calls != 3 {
+		t.Errorf("calls: got %d; want 3", calls)
+	}
+}
+
+func TestWithCircuitBreaker_HalfOpen(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		open   bool
+	}{
+		{"probe succeeds", http.StatusOK, false},
+		{"probe fails", http.StatusServiceUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clk := &manual{
+				now: time.Date(2026, time.July, 20, 12, 0, 0, 0, time.UTC),
+			}
+
+			var probing atomic.Bool
+			entered := make(chan struct{})
+			release := make(chan struct{})
+			tr := retry.NewTransport(
+				tripFunc(func(*http.Request) (*http.Response, error) {
+					status := http.StatusServiceUnavailable
+					if probing.Load() {
+						entered <- struct{}{}
+						<-release
+						status = tt.status
+					}
+					return respond(status, newBody("body")), nil
+				}),
+				retry.WithAttemptLimit(1),
+				retry.WithCircuitBreaker(1, time.Minute),
+				retry.WithClock(clk.Now),
+			)
+
+			if _, err := send(t, tr); err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+
+			probing.Store(true)
+			clk.Advance(time.Minute)
+
+			req, err := http.NewRequestWithContext(
+				t.Context(), http.MethodGet, "http://example.com", nil,
+			)
+			if err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+			done := make(chan error, 1)
+			go func() {
+				res, err := tr.RoundTrip(req)
+				if err == nil {
+					_ = res.Body.Close()
+				}
+				done <- err
+			}()
+			<-entered
+
+			// Only the probe is let through while it is pending.
+			if _, err := send(t, tr); !errors.Is(err, retry.ErrCircuitOpen) {
+				t.Errorf("got %v; want %v", err, retry.ErrCircuitOpen)
+			}
+
+			close(release)
+			if err := <-done; err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+			probing.Store(false)
+
+			_, err = send(t, tr)
+			if got := errors.Is(err, retry.ErrCircuitOpen); got != tt.open {
+				t.Errorf("open: got %t; want %t", got, tt.open)
+			}
+		})
+	}
+}
+
+func TestWithCircuitBreaker_CountsTransportErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	tr := retry.NewTransport(
+		tripFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			return nil, errors.New("connection refused")
+		}),
+		retry.WithCircuitBreaker(2, time.Hour),
+	)
+
+	for range 2 {
+		if _, err := send(t, tr); errors.Is(err, retry.ErrCircuitOpen) {
+			t.Fatalf("should not have opened the circuit yet")
+		}
+	}
+	if _, err := send(t, tr); !errors.Is(err, retry.ErrCircuitOpen) {
+		t.Errorf("got %v; want %v", err, retry.ErrCircuitOpen)
+	}
+	if calls != 2 {
+		t.Errorf("calls: got %d; want 2", calls)
+	}
+}
+
+func TestWithCircuitBreaker_IgnoresCancellation(t *testing.T) {
+	t.Parallel()
+
+	tr := retry.NewTransport(
+		tripFunc(func(*http.Request) (*http.Response, error) {
+			return nil, context.Canceled
+		}),
+		retry.WithCircuitBreaker(1, time.Hour),
+	)
+
+	for range 2 {
+		if _, err := send(t, tr); errors.Is(err, retry.ErrCircuitOpen) {
+			t.Fatalf("should not have opened the circuit")
+		}
+	}
+}
+
+func TestWithCircuitBreaker_Disabled(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	tr := retry.NewTransport(
+		counter(http.StatusServiceUnavailable, &calls),
+		retry.WithAttemptLimit(1),
+		retry.WithCircuitBreaker(1, 0),
+	)
+
+	for range 3 {
+		if _, err := send(t, tr); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("calls: got %d; want 3", calls)
+	}
+}
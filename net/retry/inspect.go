@@ -0,0 +1,70 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Inspector examines a buffered response body to decide whether the attempt
+// should be retried, for APIs that report retryable failures in the payload
+// of an otherwise successful response. It may read res.Body freely; the body
+// is rewound afterwards. A non-nil error fails the attempt with that error.
+type Inspector func(res *http.Response) (retry bool, err error)
+
+// inspect buffers the body of res, up to the configured limit, and consults
+// the inspector. The body of res remains readable from the start afterwards.
+// Bodies exceeding the limit are not inspected.
+func (t *transport) inspect(res *http.Response) (bool, error) {
+	if res.Body == nil || res.Body == http.NoBody {
+		return false, nil
+	}
+
+	orig := res.Body
+	// One byte beyond the limit distinguishes a complete body from a
+	// truncated one.
+	buf, err := io.ReadAll(io.LimitReader(orig, t.inspectLimit+1))
+	if err != nil {
+		_ = orig.Close()
+		return false, err
+	}
+
+	if int64(len(buf)) > t.inspectLimit {
+		// Restore the consumed prefix in front of the unread remainder.
+		res.Body = &splice{io.MultiReader(bytes.NewReader(buf), orig), orig}
+		return false, nil
+	}
+	_ = orig.Close()
+
+	res.Body = io.NopCloser(bytes.NewReader(buf))
+	retry, err := t.inspector(res)
+	res.Body = io.NopCloser(bytes.NewReader(buf))
+	if err != nil {
+		_ = res.Body.Close()
+		return false, fmt.Errorf("failed to inspect response body: %w", err)
+	}
+	return retry, nil
+}
+
+// splice is a response body that reads from one source but closes another.
+type splice struct {
+	io.Reader
+	c io.Closer
+}
+
+func (s *splice) Close() error { return s.c.Close() }
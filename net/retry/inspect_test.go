@@ -0,0 +1,225 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/deep-rent/nexus/net/retry"
+)
+
+// envelope inspects a JSON body for a retryable flag.
+func envelope(res *http.Response) (bool, error) {
+	var v struct {
+		Retryable bool `json:"retryable"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+		return false, err
+	}
+	return v.Retryable, nil
+}
+
+func TestWithBodyInspector(t *testing.T) {
+	t.Parallel()
+
+	bodies := []*body{
+		newBody(`{"retryable":true}`),
+		newBody(`{"retryable":false}`),
+	}
+	var calls int
+	tr := retry.NewTransport(
+		tripFunc(func(*http.Request) (*http.Response, error) {
+			b := bodies[calls]
+			calls++
+			return respond(http.StatusOK, b), nil
+		}),
+		retry.WithAttemptLimit(3),
+		retry.WithBodyInspector(envelope),
+	)
+
+	res, err := send(t, tr)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("calls: got %d; want 2", calls)
+	}
+
+	// The inspected body is handed to the caller intact.
+	got, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if want := `{"retryable":false}`; string(got) != want {
+		t.Errorf("body: got %q; want %q", got, want)
+	}
+
+	// The buffered originals are closed once consumed.
+	for i, b := range bodies {
+		if _, closed := b.stats(); !closed {
+			t.Errorf("body %d: should have been closed", i)
+		}
+	}
+}
+
+func TestWithBodyInspector_AttemptLimit(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	tr := retry.NewTransport(
+		tripFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			return respond(http.StatusOK, newBody(`{"retryable":true}`)), nil
+		}),
+		retry.WithAttemptLimit(2),
+		retry.WithBodyInspector(envelope),
+	)
+
+	if _, err := send(t, tr); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls: got %d; want 2", calls)
+	}
+}
+
+func TestWithBodyInspector_Idempotency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		key   string
+		calls int
+	}{
+		{"without key", "", 1},
+		{"with key", "abc", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			tr := retry.NewTransport(
+				tripFunc(func(*http.Request) (*http.Response, error) {
+					calls++
+					b := newBody(`{"retryable":true}`)
+					return respond(http.StatusOK, b), nil
+				}),
+				retry.WithAttemptLimit(2),
+				retry.WithBodyInspector(envelope),
+			)
+
+			req, err := http.NewRequestWithContext(
+				t.Context(), http.MethodPost, "http://example.com",
+				strings.NewReader("payload"),
+			)
+			if err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+			if tt.key != "" {
+				req.Header.Set("Idempotency-Key", tt.key)
+			}
+			res, err := tr.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+			_ = res.Body.Close()
+
+			if calls != tt.calls {
+				t.Errorf("calls: got %d; want %d", calls, tt.calls)
+			}
+		})
+	}
+}
+
+func TestWithBodyInspector_Error(t *testing.T) {
+	t.Parallel()
+
+	b := newBody("not json")
+	tr := retry.NewTransport(
+		tripFunc(func(*http.Request) (*http.Response, error) {
+			return respond(http.StatusOK, b), nil
+		}),
+		retry.WithBodyInspector(envelope),
+	)
+
+	res, err := send(t, tr)
+	if err == nil {
+		t.Fatal("should have returned an error")
+	}
+	if res != nil {
+		t.Error("should not have returned a response")
+	}
+	var syntax *json.SyntaxError
+	if !errors.As(err, &syntax) {
+		t.Errorf("got %v; want a %T", err, syntax)
+	}
+	if _, closed := b.stats(); !closed {
+		t.Error("body should have been closed")
+	}
+}
+
+func TestWithMaxInspectBytes(t *testing.T) {
+	t.Parallel()
+
+	const content = `{"retryable":true}`
+
+	var calls, inspections int
+	b := newBody(content)
+	tr := retry.NewTransport(
+		tripFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			return respond(http.StatusOK, b), nil
+		}),
+		retry.WithAttemptLimit(3),
+		retry.WithBodyInspector(func(res *http.Response) (bool, error) {
+			inspections++
+			return envelope(res)
+		}),
+		retry.WithMaxInspectBytes(4),
+	)
+
+	res, err := send(t, tr)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	// The oversized body bypasses the inspector, so no retry happens.
+	if calls != 1 || inspections != 0 {
+		t.Errorf("calls, inspections: got %d, %d; want 1, 0",
+			calls, inspections)
+	}
+
+	// The partially buffered body is still delivered in full.
+	got, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if string(got) != content {
+		t.Errorf("body: got %q; want %q", got, content)
+	}
+	if err := res.Body.Close(); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if _, closed := b.stats(); !closed {
+		t.Error("body should have been closed")
+	}
+}
@@ -15,6 +15,8 @@
 package retry
 
 import (
+	"time"
+
 	"github.com/deep-rent/nexus/std/backoff"
 	"github.com/deep-rent/nexus/std/clock"
 	"github.com/deep-rent/nexus/sys/log"
@@ -26,6 +28,10 @@ import (
 // oversized error page stall the retry loop.
 const DefaultMaxDrainBytes int64 = 64 << 10 // 64 KB
 
+// DefaultMaxInspectBytes is the default number of bytes buffered from a
+// response body for the [Inspector] set through [WithBodyInspector].
+const DefaultMaxInspectBytes int64 = 64 << 10 // 64 KB
+
 // config holds the configuration parameters supplied via functional options.
 type config struct {
 	policy  Policy           // base retry logic
@@ -34,6 +40,21 @@ type config struct {
 	logger  *log.Logger      // destination for debug output
 	now     clock.Clock      // clock used to interpret date headers
 	drain   int64            // bytes read from an abandoned response body
+	elapsed time.Duration    // time budget across all attempts
+
+	threshold int           // failures that open the circuit breaker
+	cooldown  time.Duration // time the circuit breaker stays open
+
+	maxThrottle time.Duration // cap on the delay requested by the server
+	jitter      float64       // fraction of jitter added to that delay
+
+	classify func(error) bool // extends Attempt.Transient, if set
+
+	inspector    Inspector // examines response bodies, if set
+	inspectLimit int64     // bytes buffered for the inspector
+
+	// onRetry is notified before each backoff, or nil.
+	onRetry func(Attempt, time.Duration)
 }
 
 // Option is a function that configures the retry transport.
@@ -55,13 +76,42 @@ func WithPolicy(policy Policy) Option {
 // This includes the initial attempt. A value of 3 means one initial attempt
 // and up to two retries. If the value is 0 or less, no limit is enforced,
 // which makes the [Policy] and the request context solely responsible for
-// ending the loop.
+// ending the loop, unless the backoff strategy grants a limited number of
+// retries through [backoff.WithMaxAttempts].
 func WithAttemptLimit(n int) Option {
 	return func(c *config) {
 		c.limit = n
 	}
 }
 
+// WithMaxElapsed bounds the wall-clock time spent on a request, measured from
+// the start of the first attempt. Before backing off, the transport checks
+// whether the upcoming delay would exceed this budget; if so, it stops and
+// returns the result of the last attempt, even if further attempts remain.
+// Unlike a context deadline, the budget never interrupts an attempt that is
+// already in flight.
+//
+// If the value is 0 or less, which is the default, no budget is enforced.
+func WithMaxElapsed(d time.Duration) Option {
+	return func(c *config) {
+		c.elapsed = d
+	}
+}
+
+// WithTransientClassifier extends [Attempt.Transient] by errors for which fn
+// reports true, such as protocol-specific errors like an HTTP/2 GOAWAY frame.
+// The built-in classification still applies, and context cancellations are
+// never considered transient, so fn does not see them. Since
+// [DefaultPolicy] relies on Attempt.Transient, this makes the additional
+// errors retryable without writing a custom policy. A nil value is ignored.
+func WithTransientClassifier(fn func(err error) bool) Option {
+	return func(c *config) {
+		if fn != nil {
+			c.classify = fn
+		}
+	}
+}
+
 // WithBackoff sets the strategy for calculating the delay between retries.
 //
 // Attempts are counted per request, so a single strategy can be shared by any
@@ -75,6 +125,53 @@ func WithBackoff(strategy backoff.Strategy) Option {
 	}
 }
 
+// WithOnRetry registers a callback that is invoked whenever the transport is
+// about to retry a request, right before it waits for the given delay. This
+// is a natural place to count retries or to record a trace event. The
+// [Attempt] describes the attempt that failed; by then, its response body has
+// already been drained and closed, so only the status and headers remain
+// accessible.
+//
+// The callback runs synchronously on the goroutine driving the request and
+// may be invoked concurrently for different requests. A nil value is ignored.
+func WithOnRetry(fn func(a Attempt, delay time.Duration)) Option {
+	return func(c *config) {
+		if fn != nil {
+			c.onRetry = fn
+		}
+	}
+}
+
+// WithMaxRetryAfter caps the delay honored when the server asks the client to
+// back off through the Retry-After or X-RateLimit-Reset headers. A server
+// delay within the cap is respected as usual; a longer one, such as an
+// absurd "Retry-After: 3600", is shortened to the cap rather than blocking
+// the request for an hour. The backoff strategy still applies, so the actual
+// delay never falls below the one it yields.
+//
+// If the value is 0 or less, which is the default, the server delay is
+// honored in full. Combine this option with [WithMaxElapsed] or a context
+// deadline to give up instead of waiting.
+func WithMaxRetryAfter(d time.Duration) Option {
+	return func(c *config) {
+		c.maxThrottle = d
+	}
+}
+
+// WithRetryAfterJitter extends the delay requested by the server by a random
+// fraction of up to p, which is capped to the range between 0 and 1. Clients
+// that were throttled together otherwise tend to retry in lockstep once the
+// delay elapses, prompting the server to throttle them again. Since jitter
+// only ever lengthens the delay, the server's request is still honored. The
+// jitter is applied after the cap set by [WithMaxRetryAfter].
+//
+// If not customized, no jitter is applied.
+func WithRetryAfterJitter(p float64) Option {
+	return func(c *config) {
+		c.jitter = min(1, max(0, p))
+	}
+}
+
 // WithLogger sets the [log.Logger] for debug messages.
 //
 // If not provided, debug output is discarded ([log.Discard]). A nil value
@@ -88,9 +185,9 @@ func WithLogger(logger *log.Logger) Option {
 }
 
 // WithClock provides a custom time source used to interpret the date-based
-// forms of the Retry-After and X-RateLimit-Reset headers, primarily for
-// testing. It does not affect the actual waiting between attempts, which
-// always follows the real clock.
+// forms of the Retry-After and X-RateLimit-Reset headers and to time the
+// cooldown of the circuit breaker, primarily for testing. It does not affect
+// the actual waiting between attempts, which always follows the real clock.
 //
 // If not provided, [clock.System] is used. A nil value is ignored.
 func WithClock(now clock.Clock) Option {
@@ -113,3 +210,64 @@ func WithMaxDrainBytes(n int64) Option {
 		c.drain = n
 	}
 }
+
+// WithCircuitBreaker stops sending requests to an upstream that appears to be
+// down. Once threshold attempts have failed within a window of length
+// cooldown, without a success in between, the circuit opens: for the duration
+// of cooldown, [http.RoundTripper.RoundTrip] fails fast with [ErrCircuitOpen]
+// without calling the wrapped transport, and retries already in progress are
+// abandoned, returning the result of their last attempt. After the cooldown,
+// the circuit is half-open and lets a single probe through while all other
+// requests keep failing fast. If the probe succeeds, the circuit closes;
+// otherwise, it opens again for another cooldown.
+//
+// An attempt counts as failed if the wrapped transport returned an error
+// other than a context cancellation, or if the response indicates a
+// temporary server-side failure (see [Attempt.Temporary]). The breaker is
+// shared by all requests sent through the transport.
+//
+// If the threshold or cooldown is 0 or less, no circuit breaker is used.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *config) {
+		c.threshold = threshold
+		c.cooldown = cooldown
+	}
+}
+
+// WithBodyInspector lets fn decide whether an attempt should be retried based
+// on the response body, for APIs that answer with 200 OK but report a
+// retryable failure in the payload. The inspector is consulted for every
+// response before the [Policy]. If it requests a retry, the request is
+// retried regardless of the policy, but only if it is idempotent (see
+// [Attempt.Idempotent]), and still subject to the attempt limit and to the
+// body being rewindable. If it returns an error, the attempt fails with that
+// error, which the policy then sees in [Attempt.Error].
+//
+// To make this possible, the response body is read into memory before the
+// inspector is called and replaced by a re-readable copy, which is what the
+// caller eventually receives. This costs memory proportional to the body size
+// for every in-flight request, and the response is not streamed to the caller
+// until it has been read in full. Bodies larger than the limit set through
+// [WithMaxInspectBytes] are therefore passed through without inspection.
+//
+// A nil value is ignored.
+func WithBodyInspector(fn Inspector) Option {
+	return func(c *config) {
+		if fn != nil {
+			c.inspector = fn
+		}
+	}
+}
+
+// WithMaxInspectBytes limits how much of a response body is buffered for the
+// inspector set through [WithBodyInspector]. Larger bodies are not inspected.
+//
+// If not provided, [DefaultMaxInspectBytes] is used. Values of 0 or less are
+// ignored.
+func WithMaxInspectBytes(n int64) Option {
+	return func(c *config) {
+		if n > 0 {
+			c.inspectLimit = n
+		}
+	}
+}
@@ -38,14 +38,18 @@ type Attempt struct {
 	Error error
 	// Count is the number of the current attempt, starting at 1.
 	Count int
+
+	flagged  bool             // whether the body inspector requested a retry
+	classify func(error) bool // extends Transient, if set
 }
 
 // Idempotent reports whether the request can be safely retried.
 //
 // It considers the HTTP methods defined as idempotent by RFC 9110, namely GET,
-// HEAD, OPTIONS, TRACE, PUT, and DELETE. Note that idempotency is a property
-// of the server implementation; a POST endpoint guarded by an idempotency key
-// is safe to retry even though this method reports otherwise.
+// HEAD, OPTIONS, TRACE, PUT, and DELETE. In addition, a POST or PATCH request
+// is considered idempotent if it carries a non-empty Idempotency-Key header,
+// which asks the server to deduplicate repeated deliveries. Note that
+// idempotency is ultimately a property of the server implementation.
 func (a Attempt) Idempotent() bool {
 	switch a.Request.Method {
 	case
@@ -56,6 +60,10 @@ func (a Attempt) Idempotent() bool {
 		http.MethodPut,
 		http.MethodDelete:
 		return true
+	case
+		http.MethodPost,
+		http.MethodPatch:
+		return a.Request.Header.Get("Idempotency-Key") != ""
 	default:
 		return false
 	}
@@ -90,7 +98,8 @@ func (a Attempt) Temporary() bool {
 // It returns true for network timeouts and for connections that were closed
 // mid-flight. It returns false for context cancellations ([context.Canceled],
 // [context.DeadlineExceeded]), since retrying cannot succeed once the caller
-// has given up or its deadline has passed.
+// has given up or its deadline has passed. Further errors can be classified
+// as transient through [WithTransientClassifier].
 func (a Attempt) Transient() bool {
 	if a.Error == nil ||
 		errors.Is(a.Error, context.Canceled) ||
@@ -101,7 +110,10 @@ func (a Attempt) Transient() bool {
 		return true
 	}
 	var err net.Error
-	return errors.As(a.Error, &err) && err.Timeout()
+	if errors.As(a.Error, &err) && err.Timeout() {
+		return true
+	}
+	return a.classify != nil && a.classify(a.Error)
 }
 
 // Policy is the decision-making function that determines whether to retry.
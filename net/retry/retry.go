@@ -23,6 +23,7 @@ import (
 	"github.com/deep-rent/nexus/net/header"
 	"github.com/deep-rent/nexus/std/backoff"
 	"github.com/deep-rent/nexus/std/clock"
+	"github.com/deep-rent/nexus/std/jitter"
 	"github.com/deep-rent/nexus/sys/log"
 )
 
@@ -35,6 +36,19 @@ type transport struct {
 	logger  *log.Logger       // destination for debug output
 	now     clock.Clock       // clock used to interpret date headers
 	drain   int64             // bytes read from an abandoned response body
+	elapsed time.Duration     // time budget across all attempts, if positive
+	breaker *breaker          // stops traffic to a failing upstream, if set
+
+	inspector    Inspector // examines response bodies, if set
+	inspectLimit int64     // bytes buffered for the inspector
+
+	classify func(error) bool // extends Attempt.Transient, if set
+
+	maxThrottle time.Duration  // cap on the delay requested by the server
+	jitter      *jitter.Jitter // scatters the delay requested by the server
+
+	// onRetry is notified before each backoff, or nil.
+	onRetry func(Attempt, time.Duration)
 }
 
 // NewTransport creates and returns a new retrying [http.RoundTripper].
@@ -58,18 +72,54 @@ func NewTransport(
 		logger:  log.Discard(),
 		now:     clock.System,
 		drain:   DefaultMaxDrainBytes,
+
+		inspectLimit: DefaultMaxInspectBytes,
 	}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
-	return &transport{
+	if cfg.limit <= 0 {
+		// A retry budget configured on the strategy counts retries, which
+		// excludes the initial attempt.
+		if n := backoff.MaxAttempts(cfg.backoff); n > 0 {
+			cfg.limit = n + 1
+		}
+	}
+	policy := cfg.policy
+	if cfg.inspector != nil {
+		base := policy
+		policy = func(a Attempt) bool {
+			// A flagged attempt has been processed by the server, so it is only
+			// safe to repeat if the request is idempotent.
+			return a.flagged && a.Idempotent() || base(a)
+		}
+	}
+	t := &transport{
 		next:    next,
-		policy:  cfg.policy.LimitAttempts(cfg.limit),
+		policy:  policy.LimitAttempts(cfg.limit),
 		backoff: cfg.backoff,
 		logger:  cfg.logger,
 		now:     cfg.now,
 		drain:   cfg.drain,
+		elapsed: cfg.elapsed,
+		onRetry: cfg.onRetry,
+
+		inspector:    cfg.inspector,
+		inspectLimit: cfg.inspectLimit,
+
+		classify: cfg.classify,
+
+		maxThrottle: cfg.maxThrottle,
+		jitter:      jitter.New(cfg.jitter, nil),
+	}
+	if cfg.threshold > 0 && cfg.cooldown > 0 {
+		t.breaker = &breaker{
+			threshold: cfg.threshold,
+			cooldown:  cfg.cooldown,
+			now:       cfg.now,
+		}
 	}
+	return t
 }
 
 // attemptKey carries the 1-based attempt number in a request context.
@@ -86,6 +136,18 @@ func AttemptCount(ctx context.Context) int {
 	return count
 }
 
+// Attempts reports the number of attempts it took to obtain res from a
+// retrying transport, which is useful for logging once the exchange is
+// complete. It relies on [http.Response.Request] being the request sent for
+// the final attempt, which the transport ensures. It returns 0 if res is nil
+// or was not obtained through a retrying transport.
+func Attempts(res *http.Response) int {
+	if res == nil || res.Request == nil {
+		return 0
+	}
+	return AttemptCount(res.Request.Context())
+}
+
 // RoundTrip executes an HTTP transaction, retrying it as directed by the
 // configured [Policy].
 //
@@ -97,16 +159,22 @@ func AttemptCount(ctx context.Context) int {
 // The loop honors the request context throughout. If the context carries a
 // deadline that would elapse during the next backoff delay, the transport
 // stops early and returns the result of the last attempt rather than waiting
-// for a cancellation that is certain to happen.
+// for a cancellation that is certain to happen. The same applies to the time
+// budget set through [WithMaxElapsed].
 func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	ctx := req.Context()
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	if t.breaker != nil && !t.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
 
 	// A body that cannot be rewound can only be sent once.
 	rewindable := req.Body == nil || req.GetBody != nil
 
+	start := time.Now()
+
 	for count := 1; ; count++ {
 		actx := context.WithValue(ctx, attemptKey{}, count)
 
@@ -119,13 +187,30 @@ func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		}
 
 		res, err := t.next.RoundTrip(attempt)
+		if res != nil && res.Request == nil {
+			// Required for Attempts to find the count.
+			res.Request = attempt
+		}
+
+		var flagged bool
+		if err == nil && t.inspector != nil {
+			if flagged, err = t.inspect(res); err != nil {
+				res = nil
+			}
+		}
 
-		retry := t.policy(Attempt{
+		a := Attempt{
 			Request:  attempt,
 			Response: res,
 			Error:    err,
 			Count:    count,
-		})
+			flagged:  flagged,
+			classify: t.classify,
+		}
+		if t.breaker != nil {
+			t.breaker.record(a)
+		}
+		retry := t.policy(a)
 
 		// The policy is consulted first, so that it observes every attempt
 		// even when the request turns out not to be repeatable.
@@ -156,8 +241,32 @@ func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 			return res, err
 		}
 
+		if t.elapsed > 0 && time.Since(start)+delay > t.elapsed {
+			t.logger.Debug(ctx,
+				"Not retrying, time budget would be exceeded during backoff",
+				log.Duration("delay", delay),
+				log.String("method", req.Method),
+				log.String("url", req.URL.String()),
+			)
+			return res, err
+		}
+
+		// The result of the last attempt is more useful to the caller than
+		// ErrCircuitOpen, so it is returned while its body is still intact.
+		if t.breaker != nil && !t.breaker.allow() {
+			t.logger.Debug(ctx,
+				"Not retrying, circuit breaker is open",
+				log.String("method", req.Method),
+				log.String("url", req.URL.String()),
+			)
+			return res, err
+		}
+
 		t.discard(ctx, res)
 		t.log(ctx, count, delay, req, res, err)
+		if t.onRetry != nil {
+			t.onRetry(a, delay)
+		}
 
 		if err := backoff.Wait(ctx, delay); err != nil {
 			return nil, err
@@ -190,9 +299,16 @@ func (t *transport) delay(count int, res *http.Response) time.Duration {
 	if res == nil {
 		return delay
 	}
+	throttle := header.Throttle(res.Header, t.now)
+	if t.maxThrottle > 0 {
+		throttle = min(throttle, t.maxThrottle)
+	}
+	// Jitter shortens a duration by a random fraction, which is added on top
+	// here instead, so that the server delay is still honored.
+	throttle += throttle - t.jitter.Apply(throttle)
 	// Use the longer of the two delays to respect both the server's
 	// instruction and our own backoff policy.
-	return max(delay, header.Throttle(res.Header, t.now))
+	return max(delay, throttle)
 }
 
 // discard drains and closes the body of an abandoned response, allowing the
@@ -105,28 +105,36 @@ func TestAttempt_Idempotent(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
+		name   string
 		method string
+		key    string
 		want   bool
 	}{
-		{http.MethodGet, true},
-		{http.MethodHead, true},
-		{http.MethodOptions, true},
-		{http.MethodTrace, true},
-		{http.MethodPut, true},
-		{http.MethodDelete, true},
-		{http.MethodPost, false},
-		{http.MethodPatch, false},
-		{http.MethodConnect, false},
+		{"get", http.MethodGet, "", true},
+		{"head", http.MethodHead, "", true},
+		{"options", http.MethodOptions, "", true},
+		{"trace", http.MethodTrace, "", true},
+		{"put", http.MethodPut, "", true},
+		{"delete", http.MethodDelete, "", true},
+		{"post", http.MethodPost, "", false},
+		{"patch", http.MethodPatch, "", false},
+		{"connect", http.MethodConnect, "", false},
+		{"post with key", http.MethodPost, "8e03978e", true},
+		{"patch with key", http.MethodPatch, "8e03978e", true},
+		{"connect with key", http.MethodConnect, "8e03978e", false},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.method, func(t *testing.T) {
+		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
 			req, err := http.NewRequest(tt.method, "http://example.com", nil)
 			if err != nil {
 				t.Fatalf("should not have returned an error: %v", err)
 			}
+			if tt.key != "" {
+				req.Header.Set("Idempotency-Key", tt.key)
+			}
 
 			a := retry.Attempt{Request: req}
 			if got := a.Idempotent(); got != tt.want {
@@ -199,6 +207,46 @@ func TestAttempt_Transient(t *testing.T) {
 	}
 }
 
+func TestWithTransientClassifier(t *testing.T) {
+	t.Parallel()
+
+	goAway := errors.New("http2: server sent GOAWAY")
+
+	var (
+		calls      int
+		classified []error
+	)
+	tr := retry.NewTransport(
+		tripFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			switch calls {
+			case 1:
+				return nil, goAway
+			case 2:
+				return nil, errors.New("boom")
+			default:
+				return respond(http.StatusOK, newBody("ok")), nil
+			}
+		}),
+		retry.WithTransientClassifier(func(err error) bool {
+			classified = append(classified, err)
+			return errors.Is(err, goAway)
+		}),
+	)
+
+	// The classified error is retried, while the unknown one is not.
+	_, err := send(t, tr)
+	if err == nil || err.Error() != "boom" {
+		t.Errorf("got %v; want boom", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls: got %d; want 2", calls)
+	}
+	if len(classified) != 2 {
+		t.Errorf("classified: got %d errors; want 2", len(classified))
+	}
+}
+
 // wrap wraps the given error so that only [errors.Is] can unwrap it.
 func wrap(err error) error {
 	return errors.Join(errors.New("context"), err)
@@ -406,6 +454,25 @@ func TestRoundTrip_AttemptLimit(t *testing.T) {
 	}
 }
 
+func TestRoundTrip_BackoffMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	tr := retry.NewTransport(
+		counter(http.StatusServiceUnavailable, &calls),
+		retry.WithBackoff(backoff.Limit(backoff.Constant(0), 2)),
+	)
+
+	if _, err := send(t, tr); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	// Two retries follow the initial attempt.
+	if calls != 3 {
+		t.Errorf("calls: got %d; want 3", calls)
+	}
+}
+
 // The final response must reach the caller with its body untouched, even
 // though earlier bodies were drained.
 func TestRoundTrip_PreservesFinalBody(t *testing.T) {
@@ -658,6 +725,58 @@ func TestRoundTrip_RespectsRetryAfter(t *testing.T) {
 	}
 }
 
+func TestRoundTrip_MaxRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		jitter   float64
+		min, max time.Duration
+	}{
+		{"capped", 0, 10 * time.Millisecond, 10 * time.Millisecond},
+		{"jittered", 0.5, 10 * time.Millisecond, 15 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var delay time.Duration
+			tr := retry.NewTransport(
+				tripFunc(func(*http.Request) (*http.Response, error) {
+					res := respond(http.StatusTooManyRequests, newBody(""))
+					res.Header.Set("Retry-After", "3600")
+					return res, nil
+				}),
+				retry.WithAttemptLimit(2),
+				retry.WithMaxRetryAfter(10*time.Millisecond),
+				retry.WithRetryAfterJitter(tt.jitter),
+				retry.WithOnRetry(func(_ retry.Attempt, d time.Duration) {
+					delay = d
+				}),
+			)
+
+			req, err := http.NewRequestWithContext(
+				t.Context(), http.MethodGet, "http://example.com", nil,
+			)
+			if err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+
+			res, err := tr.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+			defer res.Body.Close()
+
+			if delay < tt.min || delay > tt.max {
+				t.Errorf("delay: got %v; want between %v and %v",
+					delay, tt.min, tt.max)
+			}
+		})
+	}
+}
+
 func TestRoundTrip_StopsWhenDeadlineWouldElapse(t *testing.T) {
 	t.Parallel()
 
@@ -693,6 +812,97 @@ func TestRoundTrip_StopsWhenDeadlineWouldElapse(t *testing.T) {
 	}
 }
 
+func TestRoundTrip_MaxElapsed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		delay time.Duration
+		want  int
+	}{
+		{"within budget", 0, 3},
+		{"exceeds budget", time.Hour, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			tr := retry.NewTransport(
+				counter(http.StatusServiceUnavailable, &calls),
+				retry.WithAttemptLimit(3),
+				retry.WithBackoff(backoff.Constant(tt.delay)),
+				retry.WithMaxElapsed(time.Minute),
+			)
+
+			req, err := http.NewRequestWithContext(
+				t.Context(), http.MethodGet, "http://example.com", nil,
+			)
+			if err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+
+			res, err := tr.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+			defer res.Body.Close()
+
+			if calls != tt.want {
+				t.Errorf("calls: got %d; want %d", calls, tt.want)
+			}
+			if res.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("status: got %d; want 503", res.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRoundTrip_OnRetry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls  int
+		counts []int
+		delays []time.Duration
+	)
+	tr := retry.NewTransport(
+		counter(http.StatusServiceUnavailable, &calls),
+		retry.WithAttemptLimit(3),
+		retry.WithBackoff(backoff.Constant(time.Millisecond)),
+		retry.WithOnRetry(func(a retry.Attempt, delay time.Duration) {
+			if a.Response.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("status: got %d; want 503", a.Response.StatusCode)
+			}
+			counts = append(counts, a.Count)
+			delays = append(delays, delay)
+		}),
+	)
+
+	req, err := http.NewRequestWithContext(
+		t.Context(), http.MethodGet, "http://example.com", nil,
+	)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	res, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	defer res.Body.Close()
+
+	// The final attempt is not retried, so it is not reported.
+	if want := []int{1, 2}; !slices.Equal(counts, want) {
+		t.Errorf("counts: got %v; want %v", counts, want)
+	}
+	want := []time.Duration{time.Millisecond, time.Millisecond}
+	if !slices.Equal(delays, want) {
+		t.Errorf("delays: got %v; want %v", delays, want)
+	}
+}
+
 func TestRoundTrip_ContextCanceledDuringBackoff(t *testing.T) {
 	t.Parallel()
 
@@ -1127,3 +1337,26 @@ func TestAttemptCount(t *testing.T) {
 		t.Errorf("counts: got %v; want %v", counts, want)
 	}
 }
+
+func TestAttempts(t *testing.T) {
+	t.Parallel()
+
+	if got := retry.Attempts(nil); got != 0 {
+		t.Errorf("nil response: got %d; want 0", got)
+	}
+
+	var calls int
+	tr := retry.NewTransport(
+		counter(http.StatusServiceUnavailable, &calls),
+		retry.WithAttemptLimit(3),
+	)
+
+	res, err := send(t, tr)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	if got := retry.Attempts(res); got != 3 {
+		t.Errorf("attempts: got %d; want 3", got)
+	}
+}
@@ -19,6 +19,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
+	"slices"
+	"strings"
 
 	"uuid"
 
@@ -121,6 +124,75 @@ func Invalid(description string) *Error {
 	return Fail(http.StatusBadRequest, ReasonValidationFailed, description)
 }
 
+// BadRequest builds a 400 [Error] with the given reason, for malformed input
+// that calls for a more specific reason than [Invalid] provides.
+func BadRequest(reason, description string) *Error {
+	return Fail(http.StatusBadRequest, reason, description)
+}
+
+// Unauthorized builds a 401 [Error] for requests that lack valid credentials.
+// Handlers that expect clients to authenticate interactively should also set
+// the WWW-Authenticate header before returning it.
+func Unauthorized(description string) *Error {
+	return Fail(http.StatusUnauthorized, ReasonUnauthorized, description)
+}
+
+// Errorf builds an [Error] whose description is formatted according to the
+// format specifier, in the manner of [fmt.Errorf]. Errors wrapped with the %w
+// verb are left out of the description, which reaches the client, and become
+// the cause instead, so that they are logged and [errors.Is] and [errors.As]
+// see through the result:
+//
+//	return router.Errorf(
+//		http.StatusConflict, "conflict",
+//		"Document %s was modified concurrently. %w", id, err,
+//	)
+//
+// Since a wrapped error prints nothing, it is best placed at the end of the
+// format; surrounding whitespace is trimmed from the description. The other
+// arguments still reach the client, so they must stay free of internal
+// detail.
+func Errorf(status int, reason, format string, args ...any) *Error {
+	err := fmt.Errorf(format, args...)
+	var wrapped []error
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		wrapped = []error{u.Unwrap()}
+	case interface{ Unwrap() []error }:
+		wrapped = u.Unwrap()
+	default:
+		return Fail(status, reason, err.Error())
+	}
+	hidden := make([]any, len(args))
+	for i, arg := range args {
+		hidden[i] = arg
+		if e, ok := arg.(error); ok && contains(wrapped, e) {
+			hidden[i] = redacted{}
+		}
+	}
+	desc := strings.TrimSpace(fmt.Errorf(format, hidden...).Error())
+	return Fail(status, reason, desc).WithCause(err)
+}
+
+// contains reports whether err is one of errs. Errors of incomparable types
+// are assumed to be contained, which errs on the side of not revealing them.
+func contains(errs []error, err error) bool {
+	if !reflect.TypeOf(err).Comparable() {
+		return true
+	}
+	return slices.Contains(errs, err)
+}
+
+// redacted replaces a wrapped error when [Errorf] formats the description,
+// printing nothing in its place.
+type redacted struct{}
+
+// Error implements the error interface, so that %w accepts the value.
+func (redacted) Error() string { return "" }
+
+// Format implements [fmt.Formatter] by printing nothing.
+func (redacted) Format(fmt.State, rune) {}
+
 // ServerError builds a 500 [Error] carrying the given cause. The description
 // reaches the client, so it must stay free of internal detail; the cause is
 // logged by the router instead.
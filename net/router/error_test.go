@@ -264,6 +264,24 @@ func TestErrorConstructors(t *testing.T) {
 			http.StatusBadRequest,
 			router.ReasonValidationFailed,
 		},
+		{
+			"bad request",
+			router.BadRequest("bad_cursor", "Malformed cursor."),
+			http.StatusBadRequest,
+			"bad_cursor",
+		},
+		{
+			"unauthorized",
+			router.Unauthorized("Sign in."),
+			http.StatusUnauthorized,
+			router.ReasonUnauthorized,
+		},
+		{
+			"errorf",
+			router.Errorf(http.StatusConflict, "conflict", "Version %d.", 3),
+			http.StatusConflict,
+			"conflict",
+		},
 		{
 			"server error",
 			router.ServerError("Oops.", errors.New("cause")),
@@ -306,6 +324,51 @@ func TestError_Builders(t *testing.T) {
 	}
 }
 
+func TestErrorf(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("stale version")
+
+	t.Run("wrapped", func(t *testing.T) {
+		t.Parallel()
+		err := router.Errorf(http.StatusConflict, "conflict",
+			"Document %s is outdated. %w", "a1", cause)
+
+		want := "Document a1 is outdated."
+		if got := err.Description; got != want {
+			t.Errorf("description: got %q; want %q", got, want)
+		}
+		if !errors.Is(err, cause) {
+			t.Errorf("cause: got %v; want %v", err.Cause, cause)
+		}
+	})
+
+	t.Run("several wrapped", func(t *testing.T) {
+		t.Parallel()
+		other := errors.New("lock held")
+		err := router.Errorf(http.StatusConflict, "conflict",
+			"%w Document %s is locked. %w", cause, "a1", other)
+
+		want := "Document a1 is locked."
+		if got := err.Description; got != want {
+			t.Errorf("description: got %q; want %q", got, want)
+		}
+		if !errors.Is(err, cause) || !errors.Is(err, other) {
+			t.Errorf("cause: got %v; want both errors", err.Cause)
+		}
+	})
+
+	t.Run("not wrapped", func(t *testing.T) {
+		t.Parallel()
+		err := router.Errorf(http.StatusConflict, "conflict",
+			"document %s: %v", "a1", cause)
+
+		if err.Cause != nil {
+			t.Errorf("cause: got %v; want nil", err.Cause)
+		}
+	})
+}
+
 // A silent logger must not be paid for: nothing is formatted when the level
 // is disabled.
 func TestErrorHandler_RespectsLogLevel(t *testing.T) {
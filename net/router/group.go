@@ -0,0 +1,107 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Group registers routes on a [Router] under a shared path prefix and with
+// shared middleware. It is created by [Router.Group].
+type Group struct {
+	// r is the router the routes are registered on.
+	r *Router
+	// prefix is prepended to the path of every pattern.
+	prefix string
+	// mws is the middleware applied to every route of the group.
+	mws []Middleware
+}
+
+// Group returns a [Group] that registers routes under the given path prefix,
+// wrapping them with the given middleware:
+//
+//	api := r.Group("/api/v1", router.Adapt(auth))
+//	api.HandleFunc("GET /users/{id}", getUser) // GET /api/v1/users/{id}
+//
+// The group middleware runs inside the global middleware of the router and
+// outside the local middleware passed to an individual route. A trailing
+// slash on the prefix is ignored. It panics if the prefix is neither empty
+// nor starts with a slash, such as a prefix that carries a method or host.
+func (r *Router) Group(prefix string, mws ...Middleware) *Group {
+	return &Group{
+		r:      r,
+		prefix: clean(prefix),
+		mws:    mws,
+	}
+}
+
+// Group returns a nested [Group] that extends the prefix and middleware of g.
+// The prefix is subject to the same rules as in [Router.Group].
+func (g *Group) Group(prefix string, mws ...Middleware) *Group {
+	return &Group{
+		r:      g.r,
+		prefix: g.prefix + clean(prefix),
+		mws:    g.chain(mws),
+	}
+}
+
+// Handle registers a new route like [Router.Handle], with the prefix of the
+// group inserted in front of the path of the pattern.
+func (g *Group) Handle(pattern string, handler Handler, mws ...Middleware) {
+	g.r.Handle(g.join(pattern), handler, g.chain(mws)...)
+}
+
+// HandleFunc registers a new route like [Router.HandleFunc], with the prefix
+// of the group inserted in front of the path of the pattern.
+func (g *Group) HandleFunc(
+	pattern string,
+	fn func(*Exchange) error,
+	mws ...Middleware,
+) {
+	g.Handle(pattern, HandlerFunc(fn), mws...)
+}
+
+// Mount registers a standard [http.Handler] like [Router.Mount], with the
+// prefix of the group inserted in front of the path of the pattern.
+func (g *Group) Mount(pattern string, handler http.Handler) {
+	g.Handle(pattern, Wrap(handler))
+}
+
+// clean validates a group prefix and strips its trailing slashes.
+func clean(prefix string) string {
+	if prefix != "" && prefix[0] != '/' {
+		panic(fmt.Sprintf("group prefix %q must start with a slash", prefix))
+	}
+	return strings.TrimRight(prefix, "/")
+}
+
+// join inserts the prefix of the group in front of the path of a pattern,
+// preserving its optional method and host.
+func (g *Group) join(pattern string) string {
+	i := strings.IndexByte(pattern, '/')
+	if i < 0 {
+		return pattern // Invalid; left for the mux to reject.
+	}
+	return pattern[:i] + g.prefix + pattern[i:]
+}
+
+// chain appends route-local middleware to that of the group.
+func (g *Group) chain(mws []Middleware) []Middleware {
+	out := make([]Middleware, 0, len(g.mws)+len(mws))
+	out = append(out, g.mws...)
+	return append(out, mws...)
+}
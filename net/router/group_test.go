@@ -0,0 +1,113 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deep-rent/nexus/net/router"
+)
+
+// tag appends a marker to the X-Chain response header.
+func tag(val string) router.Middleware {
+	return func(next router.Handler) router.Handler {
+		return router.HandlerFunc(func(e *router.Exchange) error {
+			e.SetHeader("X-Chain", e.W.Header().Get("X-Chain")+val)
+			return next.ServeHTTP(e)
+		})
+	}
+}
+
+func TestRouter_Group(t *testing.T) {
+	t.Parallel()
+
+	r := router.New(router.WithMiddleware(tag("R")))
+	api := r.Group("/api/v1/", tag("G"))
+	api.HandleFunc("GET /users/{id}", func(e *router.Exchange) error {
+		return e.JSON(http.StatusOK, e.Param("id"))
+	}, tag("L"))
+	api.Group("/admin", tag("N")).HandleFunc(
+		"/stats",
+		func(e *router.Exchange) error {
+			e.NoContent()
+			return nil
+		},
+	)
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		wantCode  int
+		wantChain string
+	}{
+		{"prefixed route", http.MethodGet, "/api/v1/users/7", 200, "RGL"},
+		{"nested group", http.MethodPost, "/api/v1/admin/stats", 204, "RGN"},
+		{"method preserved", http.MethodPost, "/api/v1/users/7", 405, "R"},
+		{"unprefixed path", http.MethodGet, "/users/7", 404, "R"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got := rec.Code; got != tt.wantCode {
+				t.Errorf("status code: got %d; want %d", got, tt.wantCode)
+			}
+			if got := rec.Header().Get("X-Chain"); got != tt.wantChain {
+				t.Errorf("chain: got %q; want %q", got, tt.wantChain)
+			}
+		})
+	}
+}
+
+func TestRouter_Group_InvalidPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		prefix string
+	}{
+		{"missing slash", "api"},
+		{"method", "GET /api"},
+		{"host", "example.com/api"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("should have panicked")
+				}
+			}()
+			router.New().Group(tt.prefix)
+		})
+	}
+
+	t.Run("nested", func(t *testing.T) {
+		t.Parallel()
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("should have panicked")
+			}
+		}()
+		router.New().Group("/api").Group("v1")
+	})
+}
@@ -138,7 +138,7 @@ func Measure(opts ...middleware.MeasureOption) Middleware {
 	adapted := Adapt(middleware.Measure(opts...))
 	return func(next Handler) Handler {
 		return adapted(HandlerFunc(func(e *Exchange) error {
-			middleware.SetRoute(e.Context(), e.R.Pattern)
+			middleware.SetRoute(e.Context(), e.Pattern())
 			return next.ServeHTTP(e)
 		}))
 	}
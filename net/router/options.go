@@ -16,7 +16,9 @@ package router
 
 import (
 	"encoding/json/v2"
+	"slices"
 
+	"github.com/deep-rent/nexus/std/ascii"
 	"github.com/deep-rent/nexus/sys/log"
 )
 
@@ -30,7 +32,11 @@ func WithMiddleware(mws ...Middleware) Option {
 	}
 }
 
-// WithMaxBodySize sets the maximum allowed size for request bodies.
+// WithMaxBodySize sets the maximum allowed size for request bodies in bytes.
+// Reads beyond the limit fail, and [Exchange.BindJSON], [Exchange.BindForm],
+// and [Exchange.ReadForm] report them as a 413 [Error] with
+// [ReasonBodyTooLarge]. Nonpositive values, the default, leave the size
+// unlimited; servers exposed to untrusted clients should set a limit.
 func WithMaxBodySize(bytes int64) Option {
 	return func(r *Router) {
 		r.maxBytes = bytes
@@ -62,3 +68,67 @@ func WithLogger(logger *log.Logger) Option {
 		}
 	}
 }
+
+// WithNotFound sets the handler for requests that match no route. It
+// replaces the default, which returns a 404 [Error] with [ReasonNotFound]. A
+// nil value is ignored.
+func WithNotFound(h Handler) Option {
+	return func(r *Router) {
+		if h != nil {
+			r.notFound = h
+		}
+	}
+}
+
+// WithMethodNotAllowed sets the handler for requests whose path matches a
+// route registered only for other methods. The Allow header listing those
+// methods is already set when the handler runs. It replaces the default,
+// which returns a 405 [Error] with [ReasonMethodNotAllowed]. A nil value is
+// ignored.
+func WithMethodNotAllowed(h Handler) Option {
+	return func(r *Router) {
+		if h != nil {
+			r.methodNotAllowed = h
+		}
+	}
+}
+
+// WithoutAutoHead keeps GET routes from answering HEAD requests, which
+// [http.ServeMux] otherwise routes to them. A HEAD request then only reaches
+// routes registered for it explicitly; other paths answer it like any other
+// unsupported method, with the handler set by [WithMethodNotAllowed]. Use it
+// when handlers must not run for HEAD requests, for example because they
+// have side effects or are expensive to compute.
+func WithoutAutoHead() Option {
+	return func(r *Router) {
+		r.noAutoHead = true
+	}
+}
+
+// WithEncoder registers an encoder for the given media type, offering it to
+// clients through [Exchange.Respond]:
+//
+//	router.WithEncoder("application/xml", func(w io.Writer, v any) error {
+//		return xml.NewEncoder(w).Encode(v)
+//	})
+//
+// JSON is always on offer and remains the default; registering an encoder
+// for [MediaTypeJSON] replaces the built-in one for Respond. Registering the
+// same media type twice keeps the last encoder. Empty media types and nil
+// encoders are ignored.
+func WithEncoder(mediaType string, enc Encoder) Option {
+	mediaType = ascii.ToLower(mediaType)
+	return func(r *Router) {
+		if mediaType == "" || enc == nil {
+			return
+		}
+		if r.encoders == nil {
+			r.encoders = make(map[string]Encoder)
+			r.offers = []string{MediaTypeJSON}
+		}
+		r.encoders[mediaType] = enc
+		if !slices.Contains(r.offers, mediaType) {
+			r.offers = append(r.offers, mediaType)
+		}
+	}
+}
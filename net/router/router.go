@@ -15,12 +15,19 @@
 package router
 
 import (
+	"bytes"
 	"context"
 	"encoding/json/v2"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"uuid"
 
 	"github.com/deep-rent/nexus/dat/bind"
 	"github.com/deep-rent/nexus/dat/valid"
@@ -36,6 +43,9 @@ const (
 	ReasonWrongType = "wrong_type"
 	// ReasonEmptyBody indicates that the request body was empty.
 	ReasonEmptyBody = "empty_body"
+	// ReasonBodyTooLarge indicates that the request body exceeded the limit
+	// set via [WithMaxBodySize].
+	ReasonBodyTooLarge = "body_too_large"
 	// ReasonParseJSON indicates that there was an error parsing the JSON body.
 	ReasonParseJSON = "parse_json"
 	// ReasonParseForm indicates that there was an error parsing form data.
@@ -43,12 +53,20 @@ const (
 	// ReasonParseQuery indicates that there was an error parsing query
 	// parameters.
 	ReasonParseQuery = "parse_query"
+	// ReasonParseParam indicates that there was an error parsing a path
+	// parameter.
+	ReasonParseParam = "parse_param"
 	// ReasonValidationFailed indicates that input validation failed.
 	ReasonValidationFailed = "validation_failed"
 	// ReasonServerError indicates that an unexpected internal error occurred.
 	ReasonServerError = "server_error"
 	// ReasonNotFound indicates that the requested resource does not exist.
 	ReasonNotFound = "not_found"
+	// ReasonMethodNotAllowed indicates that the requested resource does not
+	// support the request method.
+	ReasonMethodNotAllowed = "method_not_allowed"
+	// ReasonUnauthorized indicates that the request lacks valid credentials.
+	ReasonUnauthorized = "unauthorized"
 	// ReasonRateLimit indicates that the rate limit has been exceeded.
 	ReasonRateLimit = "rate_limit"
 )
@@ -59,6 +77,8 @@ const (
 	MediaTypeJSON = "application/json"
 	// MediaTypeForm is the media type for URL-encoded form data.
 	MediaTypeForm = "application/x-www-form-urlencoded"
+	// MediaTypeEventStream is the media type for Server-Sent Events.
+	MediaTypeEventStream = "text/event-stream"
 )
 
 var formBinder = bind.New(
@@ -138,7 +158,28 @@ func (rw *responseWriter) Unwrap() http.ResponseWriter {
 	return rw.ResponseWriter
 }
 
-var _ http.ResponseWriter = (*responseWriter)(nil)
+// Flush implements [http.Flusher], so that transport middlewares wrapping the
+// writer, which commonly probe for the interface directly, can flush through
+// it.
+func (rw *responseWriter) Flush() {
+	_ = rw.FlushError()
+}
+
+// FlushError flushes the underlying writer, reaching it through any chain of
+// Unwrap methods. It returns an error wrapping [http.ErrNotSupported] if no
+// writer in the chain can flush. [http.ResponseController] prefers this method
+// over Flush, so that the error is not lost.
+func (rw *responseWriter) FlushError() error {
+	if rw.status == 0 {
+		rw.WriteHeader(http.StatusOK)
+	}
+	return http.NewResponseController(rw.ResponseWriter).Flush()
+}
+
+var (
+	_ http.ResponseWriter = (*responseWriter)(nil)
+	_ http.Flusher        = (*responseWriter)(nil)
+)
 
 // Exchange acts as a context object for a single HTTP request/response cycle.
 //
@@ -152,6 +193,10 @@ type Exchange struct {
 	W ResponseWriter
 	// jsonOpts is inherited from the parent Router.
 	jsonOpts []json.Options
+	// encoders is inherited from the parent Router.
+	encoders map[string]Encoder
+	// offers is inherited from the parent Router.
+	offers []string
 	// errorHandler allows middlewares to trigger standardized error resolution.
 	errorHandler ErrorHandler
 }
@@ -168,11 +213,73 @@ func (e *Exchange) URL() *url.URL { return e.R.URL }
 // Path returns the URL path of the request.
 func (e *Exchange) Path() string { return e.R.URL.Path }
 
+// Pattern returns the pattern of the matched route, such as
+// "GET /users/{id}", as registered with the [Router]. Unlike the concrete
+// path, it is suitable as a low-cardinality label for logs and metrics.
+//
+// It returns the empty string if no route matched, which is the case for the
+// fallbacks configured via [WithNotFound] and [WithMethodNotAllowed].
+func (e *Exchange) Pattern() string { return e.R.Pattern }
+
 // Param retrieves a path parameter by name.
 //
 // This relies on Go 1.22+ routing patterns (e.g., "GET /users/{id}").
 func (e *Exchange) Param(name string) string { return e.R.PathValue(name) }
 
+// ParamInt retrieves a path parameter by name and parses it as a base-10
+// integer.
+//
+// Like the other typed accessors, it reports a malformed or missing value as
+// a 400 [Error], so that a handler can return the error as is:
+//
+//	id, err := e.ParamInt("id")
+//	if err != nil {
+//		return err
+//	}
+func (e *Exchange) ParamInt(name string) (int, error) {
+	return param(e, name, "an integer", strconv.Atoi)
+}
+
+// ParamInt64 retrieves a path parameter by name and parses it as a base-10
+// 64-bit integer. See [Exchange.ParamInt] for the error semantics.
+func (e *Exchange) ParamInt64(name string) (int64, error) {
+	return param(e, name, "an integer", func(s string) (int64, error) {
+		return strconv.ParseInt(s, 10, 64)
+	})
+}
+
+// ParamBool retrieves a path parameter by name and parses it as a boolean,
+// accepting the values understood by [strconv.ParseBool]. See
+// [Exchange.ParamInt] for the error semantics.
+func (e *Exchange) ParamBool(name string) (bool, error) {
+	return param(e, name, "a boolean", strconv.ParseBool)
+}
+
+// ParamUUID retrieves a path parameter by name and parses it as a UUID. See
+// [Exchange.ParamInt] for the error semantics.
+func (e *Exchange) ParamUUID(name string) (uuid.UUID, error) {
+	return param(e, name, "a UUID", uuid.Parse)
+}
+
+// param parses the named path parameter, wrapping a failure in a 400 [Error]
+// that names the parameter and the expected kind of value.
+func param[T any](
+	e *Exchange,
+	name, kind string,
+	parse func(string) (T, error),
+) (T, error) {
+	v, err := parse(e.R.PathValue(name))
+	if err != nil {
+		var zero T
+		return zero, Fail(
+			http.StatusBadRequest,
+			ReasonParseParam,
+			fmt.Sprintf("path parameter %q must be %s", name, kind),
+		).WithCause(err)
+	}
+	return v, nil
+}
+
 // Query parses the URL query parameters of the request.
 func (e *Exchange) Query() url.Values { return e.R.URL.Query() }
 
@@ -207,6 +314,9 @@ func (e *Exchange) BindJSON[T any](v *T) *Error {
 	}
 
 	if err := json.UnmarshalRead(e.R.Body, v, e.jsonOpts...); err != nil {
+		if tooLarge(err) {
+			return bodyTooLarge()
+		}
 		return &Error{
 			Status:      http.StatusBadRequest,
 			Reason:      ReasonParseJSON,
@@ -290,6 +400,9 @@ func (e *Exchange) ReadForm() (url.Values, error) {
 		}
 	}
 	if err := e.R.ParseForm(); err != nil {
+		if tooLarge(err) {
+			return nil, bodyTooLarge()
+		}
 		return nil, &Error{
 			Status:      http.StatusBadRequest,
 			Reason:      ReasonParseForm,
@@ -299,6 +412,23 @@ func (e *Exchange) ReadForm() (url.Values, error) {
 	return e.R.PostForm, nil
 }
 
+// tooLarge reports whether err stems from a body exceeding the limit set via
+// [WithMaxBodySize].
+func tooLarge(err error) bool {
+	_, ok := errors.AsType[*http.MaxBytesError](err)
+	return ok
+}
+
+// bodyTooLarge builds the 413 [Error] for a body exceeding the limit set via
+// [WithMaxBodySize].
+func bodyTooLarge() *Error {
+	return &Error{
+		Status:      http.StatusRequestEntityTooLarge,
+		Reason:      ReasonBodyTooLarge,
+		Description: "request body too large",
+	}
+}
+
 // JSON encodes v as JSON and writes it to the response.
 //
 // It automatically sets the Content-Type header to [MediaTypeJSON] if it has
@@ -319,6 +449,42 @@ func (e *Exchange) JSON(code int, v any) error {
 	return err
 }
 
+// Respond encodes v in the media type preferred by the client and writes it
+// to the response.
+//
+// The media type is negotiated from the Accept header of the request among
+// JSON and the types registered via [WithEncoder], using [header.Negotiate].
+// JSON is used if the client expresses no preference or accepts none of the
+// offered types. When more than one type is on offer, the response is marked
+// with "Vary: Accept" so that caches keep the representations apart. As with
+// [Exchange.JSON], an explicitly set Content-Type header is left untouched.
+func (e *Exchange) Respond(code int, v any) error {
+	if len(e.offers) == 0 {
+		return e.JSON(code, v)
+	}
+	e.W.Header().Add("Vary", "Accept")
+
+	t := header.Negotiate(e.R.Header.Get("Accept"), e.offers)
+	enc, ok := e.encoders[t]
+	if !ok {
+		return e.JSON(code, v)
+	}
+
+	var buf bytes.Buffer
+	if err := enc(&buf, v); err != nil {
+		return err
+	}
+
+	if e.W.Header().Get("Content-Type") == "" {
+		e.SetHeader("Content-Type", t)
+	}
+
+	e.Status(code)
+
+	_, err := e.W.Write(buf.Bytes())
+	return err
+}
+
 // Form writes the values as URL-encoded form data.
 //
 // It automatically sets the Content-Type header to [MediaTypeForm] if it has
@@ -398,6 +564,9 @@ func (f HandlerFunc) ServeHTTP(e *Exchange) error { return f(e) }
 
 var _ Handler = HandlerFunc(nil)
 
+// Encoder writes v to w in a particular media type. See [WithEncoder].
+type Encoder func(w io.Writer, v any) error
+
 // ErrorHandler defines a function that handles errors returned by routes.
 type ErrorHandler func(e *Exchange, err error)
 
@@ -411,30 +580,98 @@ type Router struct {
 	maxBytes int64
 	// jsonOpts are the standard JSON options used for I/O.
 	jsonOpts []json.Options
+	// encoders maps media types to the encoders registered for them.
+	encoders map[string]Encoder
+	// offers lists the media types for content negotiation, led by JSON.
+	offers []string
 	// errorHandler processes errors returned by handlers.
 	errorHandler ErrorHandler
+	// notFound handles requests that match no route.
+	notFound Handler
+	// methodNotAllowed handles requests that match a route only by path.
+	methodNotAllowed Handler
+	// fallback is the catch-all handler for requests that match no route.
+	fallback http.Handler
+	// noAutoHead keeps GET routes from answering HEAD requests.
+	noAutoHead bool
 }
 
 // New creates a new [Router] instance with the provided options.
-// It automatically registers a catch-all handler on "/" that returns a
-// standardized [Error] with [ReasonNotFound] for unmatched routes.
+// It automatically registers a catch-all handler on "/" for unmatched routes.
+// By default, it returns a standardized [Error] with [ReasonNotFound], or with
+// [ReasonMethodNotAllowed] and an Allow header if the path matches a route
+// registered for other methods. See [WithNotFound] and
+// [WithMethodNotAllowed] for replacing these responses.
 func New(opts ...Option) *Router {
 	r := &Router{
 		Mux:          http.NewServeMux(),
 		mws:          nil,
 		errorHandler: defaultErrorHandler(log.Discard()),
+		notFound: HandlerFunc(func(*Exchange) error {
+			return NotFound("The requested route does not exist.")
+		}),
+		methodNotAllowed: HandlerFunc(func(*Exchange) error {
+			return Fail(
+				http.StatusMethodNotAllowed,
+				ReasonMethodNotAllowed,
+				"The requested route does not support this method.",
+			)
+		}),
 	}
 	for _, opt := range opts {
 		opt(r)
 	}
 
-	r.Handle("/", HandlerFunc(func(*Exchange) error {
-		return NotFound("The requested route does not exist.")
-	}))
+	fallback := r.handler(HandlerFunc(func(e *Exchange) error {
+		if allow := r.allowed(e.R); len(allow) != 0 {
+			e.W.Header().Set("Allow", strings.Join(allow, ", "))
+			return r.methodNotAllowed.ServeHTTP(e)
+		}
+		return r.notFound.ServeHTTP(e)
+	}), nil)
+	r.fallback = http.HandlerFunc(
+		func(res http.ResponseWriter, req *http.Request) {
+			// The catch-all matches no route, so it must not report one.
+			req.Pattern = ""
+			fallback.ServeHTTP(res, req)
+		},
+	)
+	r.Mux.Handle("/", r.fallback)
 
 	return r
 }
 
+// methods lists the request methods probed by [Router.allowed].
+var methods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// allowed returns the methods for which a route other than the catch-all
+// matches the path of req. The standard mux would answer such requests with
+// 405 Method Not Allowed, but the catch-all shadows that response.
+func (r *Router) allowed(req *http.Request) []string {
+	var allow []string
+	probe := req.WithContext(req.Context())
+	for _, m := range methods {
+		if m == req.Method {
+			continue
+		}
+		probe.Method = m
+		if _, p := r.Mux.Handler(probe); p != "" && p != "/" {
+			allow = append(allow, m)
+		}
+	}
+	return allow
+}
+
 // ServeHTTP satisfies the [http.Handler] interface.
 func (r *Router) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 	r.Mux.ServeHTTP(res, req)
@@ -444,18 +681,53 @@ func (r *Router) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 //
 // The pattern must follow Go 1.22+ syntax. The handler is wrapped with the
 // Router's global middleware and any local middleware provided.
+//
+// As with [http.ServeMux], a GET route also answers HEAD requests; the
+// [http.Server] sends the headers and discards the body. A handler that
+// manages HEAD itself can be registered for it explicitly, since the more
+// specific "HEAD" pattern takes precedence. [WithoutAutoHead] turns this
+// behavior off for the whole router.
 func (r *Router) Handle(
 	pattern string,
 	handler Handler,
 	mws ...Middleware,
 ) {
+	h := r.handler(handler, mws)
+	if r.noAutoHead && method(pattern) == http.MethodGet {
+		next := h
+		h = http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			// Without an explicit HEAD route, the request is treated like
+			// any other method the route does not support.
+			if req.Method == http.MethodHead {
+				r.fallback.ServeHTTP(res, req)
+				return
+			}
+			next.ServeHTTP(res, req)
+		})
+	}
+	r.Mux.Handle(pattern, h)
+}
+
+// method returns the method of a route pattern, or an empty string if the
+// pattern matches all methods.
+func method(pattern string) string {
+	i := strings.IndexAny(pattern, " \t")
+	if i < 0 {
+		return ""
+	}
+	return pattern[:i]
+}
+
+// handler wraps a handler with the Router's global middleware and the given
+// local middleware, and adapts it to the [http.Handler] interface.
+func (r *Router) handler(handler Handler, mws []Middleware) http.Handler {
 	local := make([]Middleware, 0, len(r.mws)+len(mws))
 	local = append(local, r.mws...)
 	local = append(local, mws...)
 
 	chained := Chain(handler, local...)
 
-	h := http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 		if r.maxBytes > 0 {
 			req.Body = http.MaxBytesReader(res, req.Body, r.maxBytes)
 		}
@@ -464,6 +736,8 @@ func (r *Router) Handle(
 			R:            req,
 			W:            NewResponseWriter(res),
 			jsonOpts:     r.jsonOpts,
+			encoders:     r.encoders,
+			offers:       r.offers,
 			errorHandler: r.errorHandler,
 		}
 
@@ -471,8 +745,6 @@ func (r *Router) Handle(
 			r.errorHandler(e, err)
 		}
 	})
-
-	r.Mux.Handle(pattern, h)
 }
 
 // serve runs the handler chain, converting a panic into an error so that it
@@ -17,11 +17,13 @@ package router_test
 import (
 	"encoding/json/v2"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/deep-rent/nexus/dat/valid"
@@ -628,6 +630,95 @@ func TestExchange_MetadataHelpers(t *testing.T) {
 	}
 }
 
+func TestExchange_Pattern(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	capture := func(next router.Handler) router.Handler {
+		return router.HandlerFunc(func(e *router.Exchange) error {
+			got = e.Pattern()
+			return next.ServeHTTP(e)
+		})
+	}
+	r := router.New(router.WithMiddleware(capture))
+	r.HandleFunc("GET /users/{id}", func(e *router.Exchange) error {
+		e.NoContent()
+		return nil
+	})
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"matched route", "/users/42", "GET /users/{id}"},
+		{"no route", "/missing", ""},
+	}
+
+	for _, tt := range tests {
+		got = "unset"
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+		if got != tt.want {
+			t.Errorf("%s: got %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExchange_TypedParams(t *testing.T) {
+	t.Parallel()
+
+	const id = "0190a5c8-7c1e-7b4a-9c1e-1a2b3c4d5e6f"
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetPathValue("int", "-42")
+	req.SetPathValue("big", "9007199254740993")
+	req.SetPathValue("bool", "true")
+	req.SetPathValue("uuid", id)
+	req.SetPathValue("bad", "x")
+	e := &router.Exchange{R: req, W: router.NewResponseWriter(nil)}
+
+	if got, err := e.ParamInt("int"); err != nil || got != -42 {
+		t.Errorf("int: got %d, %v; want -42, nil", got, err)
+	}
+	if got, err := e.ParamInt64("big"); err != nil || got != 9007199254740993 {
+		t.Errorf("int64: got %d, %v; want 9007199254740993, nil", got, err)
+	}
+	if got, err := e.ParamBool("bool"); err != nil || !got {
+		t.Errorf("bool: got %t, %v; want true, nil", got, err)
+	}
+	if got, err := e.ParamUUID("uuid"); err != nil || got.String() != id {
+		t.Errorf("uuid: got %v, %v; want %s, nil", got, err, id)
+	}
+
+	tests := []struct {
+		name  string
+		parse func() error
+	}{
+		{"int", func() error { _, err := e.ParamInt("bad"); return err }},
+		{"int64", func() error { _, err := e.ParamInt64("bad"); return err }},
+		{"bool", func() error { _, err := e.ParamBool("bad"); return err }},
+		{"uuid", func() error { _, err := e.ParamUUID("bad"); return err }},
+		{"missing", func() error { _, err := e.ParamInt("none"); return err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var re *router.Error
+			if err := tt.parse(); !errors.As(err, &re) {
+				t.Fatalf("got %v; want a *router.Error", err)
+			}
+			if got, want := re.Status, http.StatusBadRequest; got != want {
+				t.Errorf("status: got %d; want %d", got, want)
+			}
+			if got, want := re.Reason, router.ReasonParseParam; got != want {
+				t.Errorf("reason: got %q; want %q", got, want)
+			}
+		})
+	}
+}
+
 func TestExchange_Cookies(t *testing.T) {
 	t.Parallel()
 
@@ -718,6 +809,82 @@ func TestRouter_RouteMatching(t *testing.T) {
 	})
 }
 
+func TestRouter_Respond(t *testing.T) {
+	t.Parallel()
+
+	r := router.New(
+		router.WithEncoder("Text/Plain", func(w io.Writer, v any) error {
+			_, err := fmt.Fprint(w, v)
+			return err
+		}),
+	)
+	r.HandleFunc("GET /", func(e *router.Exchange) error {
+		return e.Respond(http.StatusOK, "hello")
+	})
+
+	tests := []struct {
+		name     string
+		accept   string
+		wantType string
+		wantBody string
+	}{
+		{"no preference", "", router.MediaTypeJSON, `"hello"`},
+		{"registered type", "text/plain", "text/plain", "hello"},
+		{"wildcard", "text/*", "text/plain", "hello"},
+		{"quality", "application/json;q=0.5, text/*", "text/plain", "hello"},
+		{"unacceptable", "application/xml", router.MediaTypeJSON, `"hello"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if got, want := rec.Code, http.StatusOK; got != want {
+				t.Errorf("status code: got %d; want %d", got, want)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.wantType {
+				t.Errorf("content type: got %q; want %q", got, tt.wantType)
+			}
+			if got, want := rec.Header().Get("Vary"), "Accept"; got != want {
+				t.Errorf("vary: got %q; want %q", got, want)
+			}
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("body: got %q; want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestExchange_RespondDefault(t *testing.T) {
+	t.Parallel()
+
+	// Without registered encoders, Respond behaves like JSON.
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "text/plain")
+	e := &router.Exchange{R: req, W: router.NewResponseWriter(rec)}
+
+	if err := e.Respond(http.StatusCreated, 42); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if got, want := rec.Code, http.StatusCreated; got != want {
+		t.Errorf("status code: got %d; want %d", got, want)
+	}
+	if got, want := rec.Header().Get("Content-Type"),
+		router.MediaTypeJSON; got != want {
+		t.Errorf("content type: got %q; want %q", got, want)
+	}
+	if got := rec.Header().Get("Vary"); got != "" {
+		t.Errorf("vary: got %q; want empty", got)
+	}
+}
+
 func TestRouter_ErrorResponse(t *testing.T) {
 	t.Parallel()
 
@@ -815,9 +982,19 @@ func TestRouter_MaxBodyLimit(t *testing.T) {
 		t.Fatalf("should not have returned an error: %v", err)
 	}
 
-	if res.StatusCode != http.StatusBadRequest &&
-		res.StatusCode != http.StatusRequestEntityTooLarge {
-		t.Errorf("got status %d; want 400 or 413", res.StatusCode)
+	defer func() { _ = res.Body.Close() }()
+
+	want := http.StatusRequestEntityTooLarge
+	if got := res.StatusCode; got != want {
+		t.Errorf("status code: got %d; want %d", got, want)
+	}
+
+	var errRes router.Error
+	if err := json.UnmarshalRead(res.Body, &errRes); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if got, want := errRes.Reason, router.ReasonBodyTooLarge; got != want {
+		t.Errorf("reason: got %q; want %q", got, want)
 	}
 }
 
@@ -992,3 +1169,183 @@ func TestRouter_NotFound(t *testing.T) {
 		)
 	}
 }
+
+func TestRouter_Head(t *testing.T) {
+	t.Parallel()
+
+	r := router.New()
+	r.HandleFunc("GET /auto", func(e *router.Exchange) error {
+		e.JSON(http.StatusOK, "body")
+		return nil
+	})
+	r.HandleFunc("GET /manual", func(e *router.Exchange) error {
+		e.JSON(http.StatusOK, "body")
+		return nil
+	})
+	r.HandleFunc("HEAD /manual", func(e *router.Exchange) error {
+		e.SetHeader("X-Head", "true")
+		e.Status(http.StatusOK)
+		return nil
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	tests := []struct {
+		path     string
+		wantHead string
+	}{
+		{"/auto", ""},
+		{"/manual", "true"},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequestWithContext(
+			t.Context(), http.MethodHead, srv.URL+tt.path, nil,
+		)
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		res, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		body, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+
+		if got, want := res.StatusCode, http.StatusOK; got != want {
+			t.Errorf("%s: status code: got %d; want %d", tt.path, got, want)
+		}
+		if len(body) != 0 {
+			t.Errorf("%s: body: got %q; want empty", tt.path, body)
+		}
+		if got := res.Header.Get("X-Head"); got != tt.wantHead {
+			t.Errorf("%s: header: got %q; want %q", tt.path, got, tt.wantHead)
+		}
+	}
+}
+
+func TestWithoutAutoHead(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	r := router.New(router.WithoutAutoHead())
+	r.HandleFunc("GET /auto", func(e *router.Exchange) error {
+		calls.Add(1)
+		e.JSON(http.StatusOK, "body")
+		return nil
+	})
+	r.HandleFunc("GET /manual", func(e *router.Exchange) error {
+		e.JSON(http.StatusOK, "body")
+		return nil
+	})
+	r.HandleFunc("HEAD /manual", func(e *router.Exchange) error {
+		e.Status(http.StatusNoContent)
+		return nil
+	})
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		wantCode  int
+		wantAllow string
+	}{
+		{"get", http.MethodGet, "/auto", http.StatusOK, ""},
+		{"head", http.MethodHead, "/auto", http.StatusMethodNotAllowed, "GET"},
+		{"explicit head", http.MethodHead, "/manual", http.StatusNoContent, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got := rec.Code; got != tt.wantCode {
+				t.Errorf("status code: got %d; want %d", got, tt.wantCode)
+			}
+			if got := rec.Header().Get("Allow"); got != tt.wantAllow {
+				t.Errorf("allow: got %q; want %q", got, tt.wantAllow)
+			}
+		})
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls: got %d; want 1", got)
+	}
+}
+
+func TestRouter_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+	r := router.New()
+	r.HandleFunc("GET /items", func(e *router.Exchange) error {
+		e.NoContent()
+		return nil
+	})
+	r.HandleFunc("DELETE /items", func(e *router.Exchange) error {
+		e.NoContent()
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/items", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got, want := w.Code, http.StatusMethodNotAllowed; got != want {
+		t.Errorf("status code: got %d; want %d", got, want)
+	}
+	if got, want := w.Header().Get("Allow"), "GET, HEAD, DELETE"; got != want {
+		t.Errorf("allow: got %q; want %q", got, want)
+	}
+
+	var errRes router.Error
+	if err := json.Unmarshal(w.Body.Bytes(), &errRes); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if got, want := errRes.Reason, router.ReasonMethodNotAllowed; got != want {
+		t.Errorf("reason: got %q; want %q", got, want)
+	}
+}
+
+func TestRouter_CustomFallbacks(t *testing.T) {
+	t.Parallel()
+	r := router.New(
+		router.WithNotFound(router.HandlerFunc(func(*router.Exchange) error {
+			return router.Fail(http.StatusGone, "gone", "custom")
+		})),
+		router.WithMethodNotAllowed(router.HandlerFunc(
+			func(e *router.Exchange) error {
+				e.Status(http.StatusTeapot)
+				return nil
+			},
+		)),
+	)
+	r.HandleFunc("GET /items", func(e *router.Exchange) error {
+		e.NoContent()
+		return nil
+	})
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/missing", http.StatusGone},
+		{http.MethodPut, "/items", http.StatusTeapot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got := w.Code; got != tt.want {
+				t.Errorf("status code: got %d; want %d", got, tt.want)
+			}
+		})
+	}
+}
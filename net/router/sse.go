@@ -0,0 +1,138 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SSEWriter sends Server-Sent Events to the client. It is created by
+// [Exchange.SSE] and is not safe for concurrent use.
+type SSEWriter struct {
+	// w is the response writer the events are written to.
+	w http.ResponseWriter
+	// rc flushes each event through any wrapping writers.
+	rc *http.ResponseController
+	// ctx is the request context, canceled once the client goes away.
+	ctx context.Context
+}
+
+// SSE starts a Server-Sent Events stream.
+//
+// It sets the Content-Type to [MediaTypeEventStream], disables caching and
+// proxy buffering, commits a 200 response, and returns a writer for the
+// events:
+//
+//	stream, err := e.SSE()
+//	if err != nil {
+//		return err
+//	}
+//	for update := range updates {
+//		if err := stream.Send("update", update); err != nil {
+//			return nil // The client went away.
+//		}
+//	}
+//	return nil
+//
+// Each event is flushed as soon as it is sent, through every wrapping writer
+// that supports flushing; compressing middleware such as gzip flushes its
+// encoder along the way. An error wrapping [http.ErrNotSupported] is returned,
+// and nothing is written, if the underlying writer cannot flush.
+func (e *Exchange) SSE() (*SSEWriter, error) {
+	if !flushable(e.W) {
+		return nil, fmt.Errorf(
+			"response writer cannot stream events: %w",
+			http.ErrNotSupported,
+		)
+	}
+
+	h := e.W.Header()
+	h.Set("Content-Type", MediaTypeEventStream)
+	h.Set("Cache-Control", "no-cache")
+	// Ask reverse proxies such as nginx not to buffer the stream.
+	h.Set("X-Accel-Buffering", "no")
+	h.Del("Content-Length")
+
+	e.Status(http.StatusOK)
+
+	s := &SSEWriter{
+		w:   e.W,
+		rc:  http.NewResponseController(e.W),
+		ctx: e.Context(),
+	}
+	if err := s.rc.Flush(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Send writes an event with the given name and data and flushes it to the
+// client. An empty name sends an unnamed event, which browsers dispatch as a
+// "message". Data spanning multiple lines is split into one data field per
+// line, as the protocol requires.
+//
+// Send returns the context error once the request context is done, typically
+// because the client disconnected, so that a sending loop can end.
+func (s *SSEWriter) Send(event, data string) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if strings.ContainsAny(event, "\r\n") {
+		return errors.New("event name must not contain line breaks")
+	}
+
+	var b strings.Builder
+	if event != "" {
+		b.WriteString("event: ")
+		b.WriteString(event)
+		b.WriteByte('\n')
+	}
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+	for line := range strings.SplitSeq(data, "\n") {
+		b.WriteString("data: ")
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+
+	if _, err := s.w.Write([]byte(b.String())); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// flushable reports whether w, or any writer it wraps, implements
+// [http.Flusher], following the same Unwrap chain as
+// [http.ResponseController]. The router's own writer always claims to flush,
+// so it is looked through.
+func flushable(w http.ResponseWriter) bool {
+	for {
+		switch t := w.(type) {
+		case *responseWriter:
+			w = t.ResponseWriter
+		case http.Flusher:
+			return true
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return false
+		}
+	}
+}
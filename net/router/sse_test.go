@@ -0,0 +1,183 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gz "github.com/deep-rent/nexus/net/middleware/gzip"
+	"github.com/deep-rent/nexus/net/router"
+)
+
+func TestExchange_SSE(t *testing.T) {
+	t.Parallel()
+
+	r := router.New()
+	r.HandleFunc("GET /events", func(e *router.Exchange) error {
+		s, err := e.SSE()
+		if err != nil {
+			return err
+		}
+		if err := s.Send("", "hello"); err != nil {
+			return err
+		}
+		return s.Send("update", "a\nb\r\nc")
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	if got, want := rec.Code, http.StatusOK; got != want {
+		t.Errorf("status code: got %d; want %d", got, want)
+	}
+	h := rec.Header()
+	want := router.MediaTypeEventStream
+	if got := h.Get("Content-Type"); got != want {
+		t.Errorf("content type: got %q; want %q", got, want)
+	}
+	if got, want := h.Get("Cache-Control"), "no-cache"; got != want {
+		t.Errorf("cache control: got %q; want %q", got, want)
+	}
+	if !rec.Flushed {
+		t.Error("response should have been flushed")
+	}
+	want = "data: hello\n\n" +
+		"event: update\ndata: a\ndata: b\ndata: c\n\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body: got %q; want %q", got, want)
+	}
+}
+
+func TestExchange_SSEInvalidEvent(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	e := &router.Exchange{
+		R: httptest.NewRequest(http.MethodGet, "/", nil),
+		W: router.NewResponseWriter(rec),
+	}
+	s, err := e.SSE()
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if err := s.Send("a\nb", "x"); err == nil {
+		t.Error("should have returned an error")
+	}
+	if got := rec.Body.String(); got != "" {
+		t.Errorf("body: got %q; want empty", got)
+	}
+}
+
+func TestExchange_SSECanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	rec := httptest.NewRecorder()
+	e := &router.Exchange{
+		R: httptest.NewRequestWithContext(ctx, http.MethodGet, "/", nil),
+		W: router.NewResponseWriter(rec),
+	}
+	s, err := e.SSE()
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	cancel()
+	if err := s.Send("", "x"); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v; want %v", err, context.Canceled)
+	}
+	if got := rec.Body.String(); got != "" {
+		t.Errorf("body: got %q; want empty", got)
+	}
+}
+
+// plainWriter is a response writer that cannot flush.
+type plainWriter struct {
+	http.ResponseWriter
+}
+
+func TestExchange_SSENotSupported(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	e := &router.Exchange{
+		R: httptest.NewRequest(http.MethodGet, "/", nil),
+		W: router.NewResponseWriter(plainWriter{rec}),
+	}
+	if _, err := e.SSE(); !errors.Is(err, http.ErrNotSupported) {
+		t.Errorf("got %v; want %v", err, http.ErrNotSupported)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "" {
+		t.Errorf("content type: got %q; want empty", got)
+	}
+}
+
+func TestExchange_SSEGzip(t *testing.T) {
+	t.Parallel()
+
+	sent := make(chan struct{})
+	r := router.New(router.WithMiddleware(router.Adapt(gz.New())))
+	r.HandleFunc("GET /events", func(e *router.Exchange) error {
+		s, err := e.SSE()
+		if err != nil {
+			return err
+		}
+		if err := s.Send("", "hello"); err != nil {
+			return err
+		}
+		<-sent
+		return nil
+	})
+
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	req, err := http.NewRequestWithContext(
+		t.Context(), http.MethodGet, srv.URL+"/events", nil,
+	)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	defer res.Body.Close()
+	defer close(sent)
+
+	if got, want := res.Header.Get("Content-Encoding"), "gzip"; got != want {
+		t.Fatalf("content encoding: got %q; want %q", got, want)
+	}
+	zr, err := gzip.NewReader(res.Body)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	// The event must arrive while the handler is still running.
+	want := "data: hello\n\n"
+	buf := make([]byte, len(want))
+	if _, err := io.ReadFull(zr, buf); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+	if got := string(buf); got != want {
+		t.Errorf("body: got %q; want %q", got, want)
+	}
+}
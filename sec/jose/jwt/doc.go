@@ -68,4 +68,10 @@
 //	  Scope: "admin",
 //	}
 //	token, err := jwt.Sign(key, claims)
+//
+// To rotate through multiple keys, create a reusable [Signer]. It reports the
+// key id of the key that signed each token:
+//
+//	signer := jwt.NewSigner(keys, jwt.WithRotationStrategy(rotor.First))
+//	token, kid, err := signer.Sign(ctx, claims)
 package jwt
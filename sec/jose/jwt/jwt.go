@@ -22,14 +22,17 @@ import (
 	"encoding/json/v2"
 	"errors"
 	"fmt"
+	"io"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/deep-rent/nexus/sec/jose/jwk"
 	"github.com/deep-rent/nexus/std/ascii"
 	"github.com/deep-rent/nexus/std/clock"
+	"github.com/deep-rent/nexus/std/rotor"
 )
 
 // Type is the media type of a JWT, as defined in RFC 7519.
@@ -73,6 +76,8 @@ type header struct {
 	Alg string `json:"alg"`
 	// Kid is the key identifier.
 	Kid string `json:"kid,omitempty"`
+	// Cty is the content type of the payload.
+	Cty string `json:"cty,omitempty"`
 }
 
 // Type returns the "typ" parameter from the header.
@@ -92,8 +97,38 @@ var (
 	// ErrInvalidSignature is returned when the token's signature differs from
 	// the computed signature.
 	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrUnsecuredToken is returned when the token's "alg" header is "none" or
+	// empty. Such tokens carry no signature and are a common vector for
+	// algorithm downgrade attacks; they are never accepted.
+	ErrUnsecuredToken = errors.New("unsecured token")
+	// ErrNestedToken is returned when the token's "cty" header declares that
+	// its payload is another JWT rather than a claims set. It is wrapped by
+	// [NestedTokenError], which carries the inner token.
+	ErrNestedToken = errors.New("nested token")
 )
 
+// NestedTokenError is returned by [Parse] for nested tokens, whose payload
+// is itself a JWT in compact serialization (as signaled by a "cty" header of
+// "JWT"). Decryption is not supported, but signed inner tokens can be handed
+// to a second [Parse] pass. Note that the signature of the outer token has
+// not been verified at this point.
+type NestedTokenError struct {
+	// Token is the inner token in compact serialization.
+	Token []byte
+}
+
+// Error implements the [error] interface.
+func (e *NestedTokenError) Error() string {
+	return ErrNestedToken.Error()
+}
+
+// Unwrap allows [errors.Is] to match against [ErrNestedToken].
+func (e *NestedTokenError) Unwrap() error {
+	return ErrNestedToken
+}
+
+var _ error = (*NestedTokenError)(nil)
+
 // Token represents a parsed, but not necessarily verified, JWT.
 // The generic type T is the user-defined claims structure.
 type Token[T Claims] interface {
@@ -186,6 +221,29 @@ type Claims interface {
 	NotBefore() time.Time
 }
 
+// TimeUntilExpiry returns the remaining lifetime of a token with the given
+// claims, measured from now until its "exp" claim. The result is negative if
+// the token has already expired, and zero if it carries no "exp" claim. This
+// is useful for deriving cache TTLs for downstream data tied to the token.
+func TimeUntilExpiry(c Claims, now time.Time) time.Duration {
+	exp := c.ExpiresAt()
+	if exp.IsZero() {
+		return 0
+	}
+	return exp.Sub(now)
+}
+
+// Age returns the time elapsed since a token with the given claims was issued,
+// as indicated by its "iat" claim. The result is negative if the token claims
+// to be issued in the future, and zero if it carries no "iat" claim.
+func Age(c Claims, now time.Time) time.Duration {
+	iat := c.IssuedAt()
+	if iat.IsZero() {
+		return 0
+	}
+	return now.Sub(iat)
+}
+
 // MutableClaims extends [Claims] with setters for standard JWT claims.
 //
 // The setter methods are not safe for concurrent use and should only be called
@@ -314,25 +372,86 @@ const dot = byte('.')
 // Parse decodes a JWT from its compact serialization format into a [Token]
 // without verifying the signature. The type parameter T specifies the target
 // struct for the token's claims. If the token is malformed or the payload does
-// not unmarshal into T (using encoding/json/v2), an error is returned.
+// not unmarshal into T (using encoding/json/v2), an error is returned. Tokens
+// whose "alg" header is "none" or empty are rejected with [ErrUnsecuredToken].
+// Nested tokens are reported through a [NestedTokenError].
 func Parse[T Claims](in []byte) (Token[T], error) {
+	return parse[T](in, false)
+}
+
+// MaxTokenSize is the maximum size in bytes of a token read by [ParseReader].
+const MaxTokenSize = 64 << 10 // 64 KiB
+
+// ParseReader is like [Parse], but reads the compact serialization from r
+// until EOF. Surrounding whitespace, such as a trailing newline, is ignored.
+// Input longer than [MaxTokenSize] is rejected without being read in full.
+//
+// The input is read into a pooled buffer and decoded in place. Only the bytes
+// retained by the returned [Token] for signature verification are copied,
+// into a single allocation. This keeps the number of allocations low when
+// parsing large batches of tokens.
+func ParseReader[T Claims](r io.Reader) (Token[T], error) {
+	data := scratch.Get().(*[]byte)
+	defer release(data)
+	lr := io.LimitedReader{R: r, N: MaxTokenSize + 1}
+	in, err := readAll((*data)[:0], &lr)
+	*data = in[:0]
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token: %w", err)
+	}
+	if len(in) > MaxTokenSize {
+		return nil, fmt.Errorf("token exceeds %d bytes", MaxTokenSize)
+	}
+	return parse[T](bytes.TrimSpace(in), true)
+}
+
+// readAll appends the contents of r to b until EOF, growing b as needed.
+func readAll(b []byte, r *io.LimitedReader) ([]byte, error) {
+	for {
+		if len(b) == cap(b) {
+			b = append(b, 0)[:len(b)]
+		}
+		n, err := r.Read(b[len(b):cap(b)])
+		b = b[:len(b)+n]
+		if err == io.EOF {
+			return b, nil
+		}
+		if err != nil {
+			return b, err
+		}
+	}
+}
+
+// parse implements [Parse] and [ParseReader]. If own is true, in is not
+// retained: the bytes the token needs are copied instead.
+func parse[T Claims](in []byte, own bool) (Token[T], error) {
 	i := bytes.IndexByte(in, dot)
 	j := bytes.LastIndexByte(in, dot)
-	if i <= 0 || i == j || j == len(in)-1 {
+	// An empty signature segment is checked only after the header has been
+	// inspected, so that unsecured tokens are reported as such.
+	if i <= 0 || i == j {
 		return nil, errors.New("expected three dot-separated segments")
 	}
-	h, err := decode(in[:i])
+	buf := scratch.Get().(*[]byte)
+	defer release(buf)
+	header, err := parseHeader(in[:i], buf)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode header: %w", err)
+		return nil, err
 	}
-	header := new(header)
-	if err := json.Unmarshal(h, header, jsonOptions); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal header: %w", err)
+	if alg := header.Alg; alg == "" || strings.EqualFold(alg, "none") {
+		return nil, ErrUnsecuredToken
 	}
-	if typ := header.Typ; typ != "" && !isJWT(typ) {
-		return nil, fmt.Errorf("unexpected token type %q", typ)
+	if j == len(in)-1 {
+		return nil, errors.New("expected three dot-separated segments")
 	}
-	c, err := decode(in[i+1 : j])
+	if cty := header.Cty; cty != "" && isJWT(cty) {
+		inner, err := decode(in[i+1 : j])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode nested token: %w", err)
+		}
+		return nil, &NestedTokenError{Token: inner}
+	}
+	c, err := decodeInto(buf, in[i+1:j])
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode claims: %w", err)
 	}
@@ -340,11 +459,26 @@ func Parse[T Claims](in []byte) (Token[T], error) {
 	if err := json.Unmarshal(c, &claims, jsonOptions); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal claims: %w", err)
 	}
-	sig, err := decode(in[j+1:])
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode signature: %w", err)
+
+	var msg, sig []byte
+	if own {
+		// The signing input and the signature share one allocation.
+		seg := in[j+1:]
+		n := base64.RawURLEncoding.DecodedLen(len(seg))
+		out := make([]byte, j+n)
+		copy(out, in[:j])
+		k, err := base64.RawURLEncoding.Decode(out[j:], seg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode signature: %w", err)
+		}
+		msg, sig = out[:j:j], out[j:j+k]
+	} else {
+		sig, err = decode(in[j+1:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode signature: %w", err)
+		}
+		msg = in[:j]
 	}
-	msg := in[:j]
 	return &token[T]{
 		header: header,
 		claims: claims,
@@ -353,6 +487,49 @@ func Parse[T Claims](in []byte) (Token[T], error) {
 	}, nil
 }
 
+// ParseHeader decodes only the JOSE header of a JWT in compact serialization.
+// The payload and signature segments are neither decoded nor required to be
+// present, which makes this function suitable for routing a token to the
+// right issuer or key set based on its "kid" and "alg" parameters before
+// committing to a full [Parse].
+//
+// The returned header is unverified and must not be trusted. Unlike [Parse],
+// this function does not reject unsecured tokens, so that tooling can still
+// inspect them. An error is returned if the header segment is empty, is not
+// valid Base64URL, or does not contain a JSON object.
+func ParseHeader(in []byte) (Header, error) {
+	if i := bytes.IndexByte(in, dot); i >= 0 {
+		in = in[:i]
+	}
+	if len(in) == 0 {
+		return nil, errors.New("expected a header segment")
+	}
+	buf := scratch.Get().(*[]byte)
+	defer release(buf)
+	header, err := parseHeader(in, buf)
+	if err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// parseHeader decodes and unmarshals the header segment of a JWT, and
+// validates its "typ" parameter. The buffer is used as decoding scratch space.
+func parseHeader(seg []byte, buf *[]byte) (*header, error) {
+	h, err := decodeInto(buf, seg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode header: %w", err)
+	}
+	header := new(header)
+	if err := json.Unmarshal(h, header, jsonOptions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal header: %w", err)
+	}
+	if typ := header.Typ; typ != "" && !isJWT(typ) {
+		return nil, fmt.Errorf("unexpected token type %q", typ)
+	}
+	return header, nil
+}
+
 // isJWT checks if the token type is a JWT.
 // It handles special case such as "application/jwt" and "at+jwt".
 func isJWT(typ string) bool {
@@ -361,6 +538,36 @@ func isJWT(typ string) bool {
 	return typ == "jwt" || strings.HasSuffix(typ, "+jwt")
 }
 
+// maxPooled caps the capacity of scratch buffers returned to the pool.
+const maxPooled = 16 << 10
+
+// scratch recycles buffers for Base64URL decoding and reading of tokens.
+var scratch = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 1<<10)
+		return &b
+	},
+}
+
+// release returns a scratch buffer to the pool unless it grew too large.
+func release(buf *[]byte) {
+	if cap(*buf) <= maxPooled {
+		scratch.Put(buf)
+	}
+}
+
+// decodeInto is like [decode], but decodes into the given scratch buffer,
+// growing it as needed. The result is only valid until the next use of the
+// buffer.
+func decodeInto(buf *[]byte, src []byte) ([]byte, error) {
+	d, err := base64.RawURLEncoding.AppendDecode((*buf)[:0], src)
+	*buf = d[:0]
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
 // decode is a helper for Base64URL decoding without padding.
 func decode(src []byte) ([]byte, error) {
 	n := base64.RawURLEncoding.DecodedLen(len(src))
@@ -398,7 +605,8 @@ var (
 	// expected issuers.
 	ErrInvalidIssuer = errors.New("invalid issuer")
 	// ErrInvalidAudience signals that the "aud" claim did not match any of the
-	// expected audiences.
+	// expected audiences, or lacked one of the audiences required by
+	// [WithAllAudiences].
 	ErrInvalidAudience = errors.New("invalid audience")
 	// ErrTokenExpired signals that the "exp" claim is in the past.
 	ErrTokenExpired = errors.New("token is expired")
@@ -407,6 +615,9 @@ var (
 	// ErrTokenTooOld signals that the "iat" claim is further in the past than
 	// the configured maximum age.
 	ErrTokenTooOld = errors.New("token is too old")
+	// ErrMissingClaim signals that a required claim is absent or empty. The
+	// returned error wraps it and names the offending claim.
+	ErrMissingClaim = errors.New("missing required claim")
 )
 
 // Verifier defines the interface for a configured, reusable JWT verifier. The
@@ -421,12 +632,23 @@ type Verifier[T Claims] interface {
 
 // verifier is the default implementation of the [Verifier] interface.
 type verifier[T Claims] struct {
-	keys      jwk.Resolver
-	issuers   []string
-	audiences []string
-	leeway    time.Duration
-	age       time.Duration
-	now       clock.Clock
+	keys         jwk.Resolver
+	issuers      []string
+	audiences    []string
+	allAudiences []string
+	leeway       time.Duration
+	age          time.Duration
+	now          clock.Clock
+	required     []string
+	ready        <-chan struct{}
+	wait         time.Duration
+}
+
+// readiness is implemented by resolvers that become usable asynchronously,
+// such as [jwk.CacheSet].
+type readiness interface {
+	// Ready returns a channel that is closed once the resolver is usable.
+	Ready() <-chan struct{}
 }
 
 var _ Verifier[Claims] = (*verifier[Claims])(nil)
@@ -444,23 +666,44 @@ func NewVerifier[T Claims](
 		opt(&cfg)
 	}
 
+	var ready <-chan struct{}
+	if r, ok := keys.(readiness); ok && cfg.wait > 0 {
+		ready = r.Ready()
+	}
+
 	return &verifier[T]{
-		keys:      keys,
-		issuers:   cfg.issuers,
-		audiences: cfg.audiences,
-		leeway:    cfg.leeway,
-		age:       cfg.age,
-		now:       cfg.now,
+		keys:         keys,
+		issuers:      cfg.issuers,
+		audiences:    cfg.audiences,
+		allAudiences: cfg.allAudiences,
+		leeway:       cfg.leeway,
+		age:          cfg.age,
+		now:          cfg.now,
+		required:     cfg.required,
+		ready:        ready,
+		wait:         cfg.wait,
 	}
 }
 
 // Verify implements the [Verifier] interface.
 func (v *verifier[T]) Verify(in []byte) (T, error) {
-	c, err := Verify[T](v.keys, in)
+	tok, err := Parse[T](in)
 	if err != nil {
 		var zero T
 		return zero, err
 	}
+	v.await()
+	if err := tok.Verify(v.keys); err != nil {
+		var zero T
+		return zero, err
+	}
+	if len(v.required) > 0 {
+		if err := require(tok.(*token[T]).msg, v.required); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+	c := tok.Claims()
 	now := v.now()
 	if len(v.issuers) > 0 && !slices.Contains(v.issuers, c.Issuer()) {
 		var zero T
@@ -479,6 +722,12 @@ func (v *verifier[T]) Verify(in []byte) (T, error) {
 			return zero, ErrInvalidAudience
 		}
 	}
+	for _, aud := range v.allAudiences {
+		if !slices.Contains(c.Audience(), aud) {
+			var zero T
+			return zero, ErrInvalidAudience
+		}
+	}
 	if nbf := c.NotBefore(); !nbf.IsZero() {
 		if now.Add(v.leeway).Before(nbf) {
 			var zero T
@@ -500,19 +749,166 @@ func (v *verifier[T]) Verify(in []byte) (T, error) {
 	return c, nil
 }
 
+// await blocks until the key resolver signals readiness or the configured
+// timeout elapses, whichever comes first. It returns immediately if the
+// resolver has no readiness signal or is already ready.
+func (v *verifier[T]) await() {
+	if v.ready == nil {
+		return
+	}
+	select {
+	case <-v.ready:
+		return
+	default:
+	}
+	t := time.NewTimer(v.wait)
+	defer t.Stop()
+	select {
+	case <-v.ready:
+	case <-t.C:
+	}
+}
+
+// require checks that each of the named claims is present in the payload of
+// the signing input and holds a non-zero JSON value.
+func require(msg []byte, names []string) error {
+	c, err := decode(msg[bytes.IndexByte(msg, dot)+1:])
+	if err != nil {
+		return fmt.Errorf("failed to decode claims: %w", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(c, &m, jsonOptions); err != nil {
+		return fmt.Errorf("failed to unmarshal claims: %w", err)
+	}
+	for _, name := range names {
+		if isZero(m[name]) {
+			return fmt.Errorf("%w %q", ErrMissingClaim, name)
+		}
+	}
+	return nil
+}
+
+// isZero reports whether v is the zero value of its JSON type.
+func isZero(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
 // Sign creates a new signed JWT using the provided [jwk.KeyPair] and claims.
 //
 // It marshals the claims using encoding/json/v2, creates a header based on
 // any type that serializes to a JSON object.
 func Sign(ctx context.Context, k jwk.KeyPair, claims any) ([]byte, error) {
-	// Prepare and marshal the header.
 	header := &header{
 		Typ: Type,
 		Alg: k.Algorithm(),
 		Kid: k.KeyID(),
 	}
+	return sign(ctx, k, header, claims)
+}
+
+// SignWithHeader is like [Sign], but merges the given parameters into the
+// protected header, e.g., to emit "cty" or a custom "typ" such as "at+jwt".
+// The "alg" and "kid" parameters are always derived from the key. The "b64"
+// and "crit" parameters would change how the token must be processed (see
+// RFC 7797), which neither [Sign] nor [Verify] implement. Supplying any of
+// these in the map results in an error. If the map does not contain a "typ"
+// parameter, it defaults to [Type].
+func SignWithHeader(
+	ctx context.Context,
+	k jwk.KeyPair,
+	params map[string]any,
+	claims any,
+) ([]byte, error) {
+	header := make(map[string]any, len(params)+3)
+	for name, value := range params {
+		switch name {
+		case "alg", "kid", "b64", "crit":
+			return nil, fmt.Errorf("header parameter %q is reserved", name)
+		}
+		header[name] = value
+	}
+	if _, ok := header["typ"]; !ok {
+		header["typ"] = Type
+	}
+	header["alg"] = k.Algorithm()
+	if kid := k.KeyID(); kid != "" {
+		header["kid"] = kid
+	}
+	return sign(ctx, k, header, claims)
+}
+
+// Signer defines the interface for a configured, reusable JWT signer that
+// draws signing keys from a rotation of [jwk.KeyPair] instances.
+type Signer interface {
+	// Sign creates a new signed JWT from the given claims, as described in
+	// [Sign], using the next key in the rotation. In addition to the token,
+	// it reports the "kid" of the key that was used, so that callers can
+	// track which key signed a given token without re-parsing it.
+	Sign(ctx context.Context, claims any) ([]byte, string, error)
+}
+
+// signer is the default implementation of the [Signer] interface.
+type signer struct {
+	keys rotor.Rotor[jwk.KeyPair]
+}
+
+var _ Signer = (*signer)(nil)
+
+// NewSigner creates a new [Signer] that rotates through the given keys
+// according to the configured [rotor.Strategy]. By default, keys are used in
+// a round-robin fashion; use [WithRotationStrategy] with [rotor.First] to pin
+// signing to the first key (e.g., the newest one) while still publishing the
+// others for verification. It panics if no keys are provided.
+func NewSigner(keys []jwk.KeyPair, opts ...SignerOption) Signer {
+	cfg := signerConfig{
+		strategy: rotor.Sequential,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &signer{
+		keys: rotor.New(cfg.strategy, keys),
+	}
+}
+
+// Sign implements the [Signer] interface.
+func (s *signer) Sign(
+	ctx context.Context,
+	claims any,
+) ([]byte, string, error) {
+	k := s.keys.Next()
+	token, err := Sign(ctx, k, claims)
+	if err != nil {
+		return nil, "", err
+	}
+	return token, k.KeyID(), nil
+}
 
-	h, err := json.Marshal(header, jsonOptions)
+// sign marshals the header and claims using encoding/json/v2 and assembles
+// the signed token in compact serialization.
+func sign(
+	ctx context.Context,
+	k jwk.KeyPair,
+	header, claims any,
+) ([]byte, error) {
+	// Marshal the header.
+	h, err := json.Marshal(header, jsonOptions, json.Deterministic(true))
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal header: %w", err)
 	}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwt_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/deep-rent/nexus/sec/jose/jwa"
+	"github.com/deep-rent/nexus/sec/jose/jwk"
+	"github.com/deep-rent/nexus/sec/jose/jwt"
+)
+
+func benchToken(b *testing.B) []byte {
+	b.Helper()
+	k, err := jwk.Generate(jwa.ES256)
+	if err != nil {
+		b.Fatalf("key generation: should not have returned an error: %v", err)
+	}
+	raw, err := jwt.Sign(b.Context(), k, &testClaims{
+		Reserved: jwt.Reserved{
+			Sub: "user_123",
+			Iss: "https://issuer.example.com",
+			Aud: jwt.Audience{"api", "web"},
+		},
+		Role: "admin",
+	})
+	if err != nil {
+		b.Fatalf("signing: should not have returned an error: %v", err)
+	}
+	return raw
+}
+
+func BenchmarkParse(b *testing.B) {
+	raw := benchToken(b)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		// Mirror the copy a caller has to make when reading from a stream.
+		in, _ := io.ReadAll(bytes.NewReader(raw))
+		if _, err := jwt.Parse[*testClaims](in); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseReader(b *testing.B) {
+	raw := benchToken(b)
+	r := bytes.NewReader(raw)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		r.Reset(raw)
+		if _, err := jwt.ParseReader[*testClaims](r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"uuid"
@@ -29,6 +30,7 @@ import (
 	"github.com/deep-rent/nexus/sec/jose/jwk"
 	"github.com/deep-rent/nexus/sec/jose/jwt"
 	"github.com/deep-rent/nexus/std/clock"
+	"github.com/deep-rent/nexus/std/rotor"
 )
 
 type testClaims struct {
@@ -105,6 +107,132 @@ func TestSignVerify_MLDSA(t *testing.T) {
 	}
 }
 
+func TestSignWithHeader(t *testing.T) {
+	t.Parallel()
+	k := mockKeyPair(t)
+	set := jwk.Singleton(k)
+
+	t.Run("custom parameters", func(t *testing.T) {
+		t.Parallel()
+		raw, err := jwt.SignWithHeader(
+			t.Context(),
+			k,
+			map[string]any{"typ": "at+jwt", "cty": "foo"},
+			&testClaims{Role: "admin"},
+		)
+		if err != nil {
+			t.Fatalf("signing: should not have returned an error: %v", err)
+		}
+		out, err := jwt.Verify[*testClaims](set, raw)
+		if err != nil {
+			t.Fatalf("verification: should not have returned an error: %v", err)
+		}
+		if got, want := out.Role, "admin"; got != want {
+			t.Errorf("role: got %q; want %q", got, want)
+		}
+
+		seg := raw[:bytes.IndexByte(raw, '.')]
+		b, err := base64.RawURLEncoding.DecodeString(string(seg))
+		if err != nil {
+			t.Fatalf("decoding: should not have returned an error: %v", err)
+		}
+		var h map[string]string
+		if err := json.Unmarshal(b, &h); err != nil {
+			t.Fatalf("unmarshaling: should not have returned an error: %v", err)
+		}
+		want := map[string]string{
+			"typ": "at+jwt",
+			"cty": "foo",
+			"alg": k.Algorithm(),
+			"kid": k.KeyID(),
+		}
+		for name, v := range want {
+			if got := h[name]; got != v {
+				t.Errorf("%s: got %q; want %q", name, got, v)
+			}
+		}
+	})
+
+	t.Run("default type", func(t *testing.T) {
+		t.Parallel()
+		raw, err := jwt.SignWithHeader(t.Context(), k, nil, &testClaims{})
+		if err != nil {
+			t.Fatalf("signing: should not have returned an error: %v", err)
+		}
+		if _, err := jwt.Verify[*testClaims](set, raw); err != nil {
+			t.Fatalf("verification: should not have returned an error: %v", err)
+		}
+	})
+
+	for _, name := range []string{"alg", "kid", "b64", "crit"} {
+		t.Run("reserved "+name, func(t *testing.T) {
+			t.Parallel()
+			_, err := jwt.SignWithHeader(
+				t.Context(),
+				k,
+				map[string]any{name: "foo"},
+				&testClaims{},
+			)
+			if err == nil {
+				t.Fatal("should have returned an error")
+			}
+		})
+	}
+}
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+	k1 := mockKeyPair(t)
+	k2 := mockKeyPair(t)
+	set := jwk.NewSet(k1, k2)
+
+	tests := []struct {
+		name string
+		opts []jwt.SignerOption
+		want []string
+	}{
+		{
+			name: "round robin",
+			want: []string{k1.KeyID(), k2.KeyID(), k1.KeyID()},
+		},
+		{
+			name: "always first",
+			opts: []jwt.SignerOption{jwt.WithRotationStrategy(rotor.First)},
+			want: []string{k1.KeyID(), k1.KeyID(), k1.KeyID()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := jwt.NewSigner([]jwk.KeyPair{k1, k2}, tt.opts...)
+			for i, want := range tt.want {
+				raw, kid, err := s.Sign(t.Context(), &testClaims{})
+				if err != nil {
+					t.Fatalf("signing: should not have returned an error: %v",
+						err)
+				}
+				if kid != want {
+					t.Errorf("on call %d: got kid %q; want %q", i+1, kid, want)
+				}
+				tok, err := jwt.Parse[*testClaims](raw)
+				if err != nil {
+					t.Fatalf("parsing: should not have returned an error: %v",
+						err)
+				}
+				if got := tok.Header().KeyID(); got != kid {
+					t.Errorf("on call %d: got header kid %q; want %q",
+						i+1, got, kid)
+				}
+				if err := tok.Verify(set); err != nil {
+					t.Errorf("verify: should not have returned an error: %v",
+						err)
+				}
+			}
+		})
+	}
+}
+
 func TestVerifier_Validation(t *testing.T) {
 	t.Parallel()
 	k := mockKeyPair(t)
@@ -192,6 +320,116 @@ func TestVerifier_Validation(t *testing.T) {
 	}
 }
 
+func TestVerifier_AllAudiences(t *testing.T) {
+	t.Parallel()
+	k := mockKeyPair(t)
+	set := jwk.Singleton(k)
+
+	raw, err := jwt.Sign(t.Context(), k, &testClaims{
+		Reserved: jwt.Reserved{Aud: []string{"a", "b", "c"}},
+	})
+	if err != nil {
+		t.Fatalf("signing: should not have returned an error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    []jwt.VerifierOption
+		wantErr error
+	}{
+		{
+			name: "subset",
+			opts: []jwt.VerifierOption{jwt.WithAllAudiences("a", "c")},
+		},
+		{
+			name: "exact",
+			opts: []jwt.VerifierOption{jwt.WithAllAudiences("a", "b", "c")},
+		},
+		{
+			name:    "missing one",
+			opts:    []jwt.VerifierOption{jwt.WithAllAudiences("a", "d")},
+			wantErr: jwt.ErrInvalidAudience,
+		},
+		{
+			name: "combined with any",
+			opts: []jwt.VerifierOption{
+				jwt.WithAudiences("x", "b"),
+				jwt.WithAllAudiences("a"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := jwt.NewVerifier[*testClaims](set, tt.opts...)
+			if _, err := v.Verify(raw); !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v; want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// lazyResolver is a resolver that only finds keys after becoming ready.
+type lazyResolver struct {
+	set   jwk.Set
+	ready chan struct{}
+}
+
+func (r *lazyResolver) Find(hint jwk.Hint) jwk.Key {
+	select {
+	case <-r.ready:
+		return r.set.Find(hint)
+	default:
+		return nil
+	}
+}
+
+func (r *lazyResolver) Ready() <-chan struct{} { return r.ready }
+
+func TestVerifier_ReadyTimeout(t *testing.T) {
+	t.Parallel()
+	k := mockKeyPair(t)
+	raw, err := jwt.Sign(t.Context(), k, &testClaims{})
+	if err != nil {
+		t.Fatalf("signing: should not have returned an error: %v", err)
+	}
+
+	t.Run("becomes ready", func(t *testing.T) {
+		t.Parallel()
+		r := &lazyResolver{set: jwk.Singleton(k), ready: make(chan struct{})}
+		v := jwt.NewVerifier[*testClaims](r, jwt.WithReadyTimeout(time.Minute))
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(r.ready)
+		}()
+		if _, err := v.Verify(raw); err != nil {
+			t.Errorf("should not have returned an error: %v", err)
+		}
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		t.Parallel()
+		r := &lazyResolver{set: jwk.Singleton(k), ready: make(chan struct{})}
+		v := jwt.NewVerifier[*testClaims](
+			r,
+			jwt.WithReadyTimeout(10*time.Millisecond),
+		)
+		if _, err := v.Verify(raw); !errors.Is(err, jwt.ErrKeyNotFound) {
+			t.Errorf("got error %v; want %v", err, jwt.ErrKeyNotFound)
+		}
+	})
+
+	t.Run("no timeout", func(t *testing.T) {
+		t.Parallel()
+		r := &lazyResolver{set: jwk.Singleton(k), ready: make(chan struct{})}
+		v := jwt.NewVerifier[*testClaims](r)
+		if _, err := v.Verify(raw); !errors.Is(err, jwt.ErrKeyNotFound) {
+			t.Errorf("got error %v; want %v", err, jwt.ErrKeyNotFound)
+		}
+	})
+}
+
 func TestVerifier_TimeConstraints(t *testing.T) {
 	t.Parallel()
 	k := mockKeyPair(t)
@@ -232,6 +470,56 @@ func TestVerifier_TimeConstraints(t *testing.T) {
 	})
 }
 
+func TestTimeUntilExpiry(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		exp  time.Time
+		want time.Duration
+	}{
+		{"valid", now.Add(time.Hour), time.Hour},
+		{"expired", now.Add(-time.Minute), -time.Minute},
+		{"absent", time.Time{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := &jwt.Reserved{Exp: tt.exp}
+			if got := jwt.TimeUntilExpiry(c, now); got != tt.want {
+				t.Errorf("got %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAge(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		iat  time.Time
+		want time.Duration
+	}{
+		{"past", now.Add(-time.Hour), time.Hour},
+		{"future", now.Add(time.Minute), -time.Minute},
+		{"absent", time.Time{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := &jwt.Reserved{Iat: tt.iat}
+			if got := jwt.Age(c, now); got != tt.want {
+				t.Errorf("got %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestOmitEmpty(t *testing.T) {
 	t.Parallel()
 	k := mockKeyPair(t)
@@ -340,24 +628,29 @@ func TestParse_Errors(t *testing.T) {
 		{"bad header json", "dGVzdA.b.c", "failed to unmarshal header"},
 		{
 			"bad typ",
-			"eyJ0eXAiOiJmb28ifQ.e30.c",
+			"eyJ0eXAiOiJmb28iLCJhbGciOiJFUzI1NiJ9.e30.c",
 			"unexpected token type \"foo\"",
 		},
 		{
 			"bad claims base64",
-			"eyJ0eXAiOiJKV1QifQ.!!!.c",
+			"eyJ0eXAiOiJKV1QiLCJhbGciOiJFUzI1NiJ9.!!!.c",
 			"failed to decode claims",
 		},
 		{
 			"bad claims json",
-			"eyJ0eXAiOiJKV1QifQ.dGVzdA.c",
+			"eyJ0eXAiOiJKV1QiLCJhbGciOiJFUzI1NiJ9.dGVzdA.c",
 			"failed to unmarshal claims",
 		},
 		{
 			"bad sig base64",
-			"eyJ0eXAiOiJKV1QifQ.e30.!!!",
+			"eyJ0eXAiOiJKV1QiLCJhbGciOiJFUzI1NiJ9.e30.!!!",
 			"failed to decode signature",
 		},
+		{
+			"missing alg",
+			"eyJ0eXAiOiJKV1QifQ.e30.c",
+			"unsecured token",
+		},
 	}
 
 	for _, tt := range tests {
@@ -374,6 +667,216 @@ func TestParse_Errors(t *testing.T) {
 	}
 }
 
+func TestParse_Unsecured(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"none", "eyJhbGciOiJub25lIn0.e30."},
+		{"none with signature", "eyJhbGciOiJub25lIn0.e30.c2ln"},
+		{"empty", "eyJ0eXAiOiJKV1QiLCJhbGciOiIifQ.e30.c2ln"},
+		{"absent", "eyJ0eXAiOiJKV1QifQ.e30.c2ln"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := jwt.Parse[*testClaims]([]byte(tt.in))
+			if !errors.Is(err, jwt.ErrUnsecuredToken) {
+				t.Errorf("got error %v; want %v", err, jwt.ErrUnsecuredToken)
+			}
+		})
+	}
+}
+
+func TestParse_Nested(t *testing.T) {
+	t.Parallel()
+	k := mockKeyPair(t)
+	set := jwk.Singleton(k)
+
+	inner, err := jwt.Sign(t.Context(), k, &testClaims{Role: "admin"})
+	if err != nil {
+		t.Fatalf("signing: should not have returned an error: %v", err)
+	}
+	// The payload of a nested token is the inner token itself.
+	h := base64.RawURLEncoding.EncodeToString(
+		[]byte(`{"alg":"ES256","cty":"JWT"}`),
+	)
+	p := base64.RawURLEncoding.EncodeToString(inner)
+	raw := []byte(h + "." + p + ".c2ln")
+
+	_, err = jwt.Parse[*testClaims](raw)
+	if !errors.Is(err, jwt.ErrNestedToken) {
+		t.Fatalf("got error %v; want %v", err, jwt.ErrNestedToken)
+	}
+	var nested *jwt.NestedTokenError
+	if !errors.As(err, &nested) {
+		t.Fatalf("should have returned a %T", nested)
+	}
+	if !bytes.Equal(nested.Token, inner) {
+		t.Errorf("got inner token %q; want %q", nested.Token, inner)
+	}
+	out, err := jwt.Verify[*testClaims](set, nested.Token)
+	if err != nil {
+		t.Fatalf("verification: should not have returned an error: %v", err)
+	}
+	if got, want := out.Role, "admin"; got != want {
+		t.Errorf("role: got %q; want %q", got, want)
+	}
+}
+
+func TestParseReader(t *testing.T) {
+	t.Parallel()
+	k := mockKeyPair(t)
+	set := jwk.Singleton(k)
+	raw, err := jwt.Sign(t.Context(), k, &testClaims{Role: "admin"})
+	if err != nil {
+		t.Fatalf("signing: should not have returned an error: %v", err)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		in := append(bytes.Clone(raw), '\n')
+		tok, err := jwt.ParseReader[*testClaims](bytes.NewReader(in))
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		if got, want := tok.Claims().Role, "admin"; got != want {
+			t.Errorf("role: got %q; want %q", got, want)
+		}
+		if err := tok.Verify(set); err != nil {
+			t.Errorf("verification: should not have returned an error: %v", err)
+		}
+	})
+
+	t.Run("independent of later reads", func(t *testing.T) {
+		t.Parallel()
+		tok, err := jwt.ParseReader[*testClaims](bytes.NewReader(raw))
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		// Reuse the pooled buffers for other input.
+		for range 4 {
+			junk := strings.NewReader(strings.Repeat("x", len(raw)))
+			_, _ = jwt.ParseReader[*testClaims](junk)
+		}
+		if err := tok.Verify(set); err != nil {
+			t.Errorf("verification: should not have returned an error: %v", err)
+		}
+	})
+
+	t.Run("oversized", func(t *testing.T) {
+		t.Parallel()
+		in := strings.NewReader(strings.Repeat("a", jwt.MaxTokenSize+100))
+		if _, err := jwt.ParseReader[*testClaims](in); err == nil {
+			t.Fatal("should have returned an error")
+		}
+		// Reading stops right after the limit.
+		if got, want := in.Len(), 99; got != want {
+			t.Errorf("unread bytes: got %d; want %d", got, want)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+		_, err := jwt.ParseReader[*testClaims](strings.NewReader("a.b"))
+		if err == nil {
+			t.Fatal("should have returned an error")
+		}
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		t.Parallel()
+		want := errors.New("boom")
+		_, err := jwt.ParseReader[*testClaims](iotest.ErrReader(want))
+		if !errors.Is(err, want) {
+			t.Errorf("got error %v; want %v", err, want)
+		}
+	})
+}
+
+func TestParseHeader(t *testing.T) {
+	t.Parallel()
+	k := mockKeyPair(t)
+	raw, err := jwt.Sign(t.Context(), k, &testClaims{Role: "user"})
+	if err != nil {
+		t.Fatalf("signing: should not have returned an error: %v", err)
+	}
+
+	t.Run("full token", func(t *testing.T) {
+		t.Parallel()
+		h, err := jwt.ParseHeader(raw)
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		if got, want := h.KeyID(), k.KeyID(); got != want {
+			t.Errorf("kid: got %q; want %q", got, want)
+		}
+		if got, want := h.Algorithm(), k.Algorithm(); got != want {
+			t.Errorf("alg: got %q; want %q", got, want)
+		}
+	})
+
+	t.Run("header only", func(t *testing.T) {
+		t.Parallel()
+		h, err := jwt.ParseHeader(raw[:bytes.IndexByte(raw, '.')])
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		if got, want := h.KeyID(), k.KeyID(); got != want {
+			t.Errorf("kid: got %q; want %q", got, want)
+		}
+	})
+
+	t.Run("garbage payload", func(t *testing.T) {
+		t.Parallel()
+		in := "eyJ0eXAiOiJKV1QiLCJhbGciOiJFUzI1NiJ9.!!!"
+		h, err := jwt.ParseHeader([]byte(in))
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		if got, want := h.Algorithm(), "ES256"; got != want {
+			t.Errorf("alg: got %q; want %q", got, want)
+		}
+	})
+
+	t.Run("unsecured", func(t *testing.T) {
+		t.Parallel()
+		h, err := jwt.ParseHeader([]byte("eyJhbGciOiJub25lIn0.e30."))
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		if got, want := h.Algorithm(), "none"; got != want {
+			t.Errorf("alg: got %q; want %q", got, want)
+		}
+	})
+
+	errs := []struct {
+		name    string
+		in      string
+		wantErr string
+	}{
+		{"empty", "", "expected a header segment"},
+		{"empty header", ".e30.c", "expected a header segment"},
+		{"bad base64", "!!!.e30.c", "failed to decode header"},
+		{"bad json", "dGVzdA", "failed to unmarshal header"},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := jwt.ParseHeader([]byte(tt.in))
+			if err == nil {
+				t.Fatal("should have returned an error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %q; want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestVerify_Errors(t *testing.T) {
 	t.Parallel()
 	k1 := mockKeyPair(t)
@@ -483,8 +986,11 @@ func TestParse_ValidTypes(t *testing.T) {
 	for _, typ := range validTypes {
 		t.Run(typ, func(t *testing.T) {
 			t.Parallel()
-			// Header JSON: {"typ":"<typ>"}
-			headerJSON, err := json.Marshal(map[string]string{"typ": typ})
+			// Header JSON: {"typ":"<typ>","alg":"ES256"}
+			headerJSON, err := json.Marshal(map[string]string{
+				"typ": typ,
+				"alg": "ES256",
+			})
 			if err != nil {
 				t.Fatalf(
 					"header marshalling: should not have returned an error: %v",
@@ -502,3 +1008,62 @@ func TestParse_ValidTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifier_Required(t *testing.T) {
+	t.Parallel()
+	k := mockKeyPair(t)
+	set := jwk.Singleton(k)
+
+	raw, err := jwt.Sign(t.Context(), k, map[string]any{
+		"sub":    "user_123",
+		"tenant": "acme",
+		"empty":  "",
+		"zero":   0,
+		"null":   nil,
+	})
+	if err != nil {
+		t.Fatalf("signing: should not have returned an error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    []jwt.VerifierOption
+		wantErr bool
+	}{
+		{"subject", []jwt.VerifierOption{jwt.WithRequiredSubject()}, false},
+		{"custom", []jwt.VerifierOption{jwt.WithRequired("tenant")}, false},
+		{"absent", []jwt.VerifierOption{jwt.WithRequired("missing")}, true},
+		{"empty string", []jwt.VerifierOption{jwt.WithRequired("empty")}, true},
+		{"zero number", []jwt.VerifierOption{jwt.WithRequired("zero")}, true},
+		{"null", []jwt.VerifierOption{jwt.WithRequired("null")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := jwt.NewVerifier[*testClaims](set, tt.opts...)
+			_, err := v.Verify(raw)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("should not have returned an error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, jwt.ErrMissingClaim) {
+				t.Fatalf("got error %v; want %v", err, jwt.ErrMissingClaim)
+			}
+		})
+	}
+
+	t.Run("error names claim", func(t *testing.T) {
+		t.Parallel()
+		v := jwt.NewVerifier[*testClaims](
+			set,
+			jwt.WithRequired("tenant", "org"),
+		)
+		_, err := v.Verify(raw)
+		if err == nil || !strings.Contains(err.Error(), `"org"`) {
+			t.Errorf("got error %v; want it to name %q", err, "org")
+		}
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/deep-rent/nexus/std/clock"
+	"github.com/deep-rent/nexus/std/rotor"
 )
 
 // VerifierOption defines a functional option for configuring a [Verifier].
@@ -25,11 +26,14 @@ type VerifierOption func(*verifierConfig)
 
 // verifierConfig holds the configuration options for a [Verifier].
 type verifierConfig struct {
-	issuers   []string      // Set of trusted issuers
-	audiences []string      // Set of trusted audiences
-	leeway    time.Duration // Clock skew tolerance
-	age       time.Duration // Maximum allowed token age
-	now       clock.Clock   // Time source for temporal validation
+	issuers      []string      // Set of trusted issuers
+	audiences    []string      // Set of trusted audiences
+	allAudiences []string      // Set of audiences that must all be present
+	leeway       time.Duration // Clock skew tolerance
+	age          time.Duration // Maximum allowed token age
+	now          clock.Clock   // Time source for temporal validation
+	required     []string      // Names of claims that must be present
+	wait         time.Duration // Maximum time to wait for key readiness
 }
 
 // WithIssuers adds one or more trusted issuers to the verifier. If a token's
@@ -52,6 +56,37 @@ func WithAudiences(aud ...string) VerifierOption {
 	}
 }
 
+// WithAllAudiences adds one or more audiences that the token must be addressed
+// to simultaneously. If the token's "aud" claim is missing or does not contain
+// every one of these values, it will be rejected. Unlike [WithAudiences], a
+// single match is not sufficient. This option can be used multiple times to
+// append additional values. Both options can be combined. By default, no such
+// validation is performed.
+func WithAllAudiences(aud ...string) VerifierOption {
+	return func(c *verifierConfig) {
+		c.allAudiences = append(c.allAudiences, aud...)
+	}
+}
+
+// WithRequired adds one or more claims that must be present in the token. A
+// claim is considered missing if it is absent from the payload or holds a zero
+// JSON value (null, false, 0, "", [], or {}). Names refer to the top-level
+// JSON member names of the payload, so custom claims are supported regardless
+// of how they are mapped onto the claims struct. This option can be used
+// multiple times to append additional names. By default, no claims are
+// required.
+func WithRequired(name ...string) VerifierOption {
+	return func(c *verifierConfig) {
+		c.required = append(c.required, name...)
+	}
+}
+
+// WithRequiredSubject is a shorthand for [WithRequired] that demands a
+// non-empty "sub" claim.
+func WithRequiredSubject() VerifierOption {
+	return WithRequired("sub")
+}
+
 // WithLeeway sets a grace period to allow for clock skew in temporal
 // validations of the "exp", "nbf", and "iat" claims. It is subtracted from or
 // added to the current time as appropriate. The default is zero, meaning no
@@ -75,6 +110,22 @@ func WithMaxAge(d time.Duration) VerifierOption {
 	}
 }
 
+// WithReadyTimeout sets the maximum time to wait for the key resolver to
+// become ready before verifying a token. It only takes effect if the resolver
+// exposes a readiness signal in the form of a Ready() <-chan struct{} method,
+// as [jwk.CacheSet] does. This avoids spurious [ErrKeyNotFound] failures for
+// tokens that arrive during startup, before the first fetch of a remote key
+// set has completed. Once the resolver is ready, no further waiting occurs. If
+// the timeout elapses, verification proceeds regardless. The default is zero,
+// meaning no waiting. Negative values will be ignored.
+func WithReadyTimeout(d time.Duration) VerifierOption {
+	return func(c *verifierConfig) {
+		if d > 0 {
+			c.wait = d
+		}
+	}
+}
+
 // WithClock sets the function used to retrieve the current time during
 // validation. This is useful for deterministic testing or synchronizing with
 // an external time source. The default is [clock.System].
@@ -85,3 +136,21 @@ func WithClock(now clock.Clock) VerifierOption {
 		}
 	}
 }
+
+// SignerOption defines a functional option for configuring a [Signer].
+type SignerOption func(*signerConfig)
+
+// signerConfig holds the configuration options for a [Signer].
+type signerConfig struct {
+	strategy rotor.Strategy // Key rotation strategy
+}
+
+// WithRotationStrategy sets the strategy used to select the signing key for
+// each token. The default is [rotor.Sequential], which rotates through the
+// keys in a round-robin fashion. Use [rotor.First] to always sign with the
+// first key.
+func WithRotationStrategy(s rotor.Strategy) SignerOption {
+	return func(c *signerConfig) {
+		c.strategy = s
+	}
+}
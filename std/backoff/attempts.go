@@ -16,9 +16,14 @@ package backoff
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrExhausted is returned by [Attempts.Wait] once the retry budget granted
+// through [Limit] or [WithMaxAttempts] has been used up.
+var ErrExhausted = errors.New("retry budget exhausted")
+
 // Attempts is a running counter over a [Strategy], scoped to a single retried
 // operation.
 //
@@ -26,8 +31,9 @@ import (
 // for concurrent use. Create one per operation; sharing it between operations
 // makes them inflate each other's delays and reset each other's progress.
 type Attempts struct {
-	s Strategy // underlying strategy supplying the delays
-	n int      // number of delays handed out so far
+	s   Strategy // underlying strategy supplying the delays
+	n   int      // number of delays handed out so far
+	max int      // retries granted by s, or 0 if unlimited
 }
 
 // Count returns an [Attempts] counter that draws its delays from s. It panics
@@ -36,7 +42,7 @@ func Count(s Strategy) *Attempts {
 	if s == nil {
 		panic("count requires a non-nil strategy")
 	}
-	return &Attempts{s: s}
+	return &Attempts{s: s, max: MaxAttempts(s)}
 }
 
 // Next advances the counter and returns the delay preceding the next attempt.
@@ -46,11 +52,22 @@ func (a *Attempts) Next() time.Duration {
 }
 
 // Wait advances the counter and blocks for the resulting delay, returning
-// early if ctx is canceled. See [Wait] for the error semantics.
+// early if ctx is canceled. See [Wait] for the error semantics. If the counter
+// is exhausted, it returns [ErrExhausted] right away instead.
 func (a *Attempts) Wait(ctx context.Context) error {
+	if a.Exhausted() {
+		return ErrExhausted
+	}
 	return Wait(ctx, a.Next())
 }
 
+// Exhausted reports whether the counter has handed out as many delays as the
+// underlying strategy grants through [Limit]. It is always false for a
+// strategy without a limit.
+func (a *Attempts) Exhausted() bool {
+	return a.max > 0 && a.n >= a.max
+}
+
 // Count reports how many delays have been handed out since the counter was
 // created or last reset.
 func (a *Attempts) Count() int { return a.n }
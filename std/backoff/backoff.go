@@ -15,6 +15,8 @@
 package backoff
 
 import (
+	"math/rand/v2"
+	"sync"
 	"time"
 
 	"github.com/deep-rent/nexus/std/jitter"
@@ -44,11 +46,39 @@ type Strategy interface {
 
 // Rand is a minimal source of randomness used to compute jitter. It is
 // satisfied by [math/rand/v2.Rand].
+//
+// Since strategies are shared by concurrently retried operations, a Rand is
+// called from multiple goroutines at once and must be safe for concurrent
+// use. A [rand.Rand] is not; wrap it in [LockedRand] before passing it on.
 type Rand interface {
 	// Float64 generates a pseudo-random number in [0.0, 1.0).
 	Float64() float64
 }
 
+// locked guards a [rand.Rand] with a mutex.
+type locked struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+// LockedRand adapts r for concurrent use by serializing access to it. This
+// allows a deterministic generator, as used in tests, or one backed by a
+// different source, such as [rand.ChaCha8], to be shared by a strategy. It
+// panics if r is nil.
+func LockedRand(r *rand.Rand) Rand {
+	if r == nil {
+		panic("locked rand requires a non-nil generator")
+	}
+	return &locked{r: r}
+}
+
+// Float64 draws a number from the guarded generator.
+func (l *locked) Float64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Float64()
+}
+
 // New creates a backoff [Strategy] from the provided options.
 //
 // The returned strategy is exponential by default. It degrades to a linear
@@ -82,7 +112,7 @@ func New(opts ...Option) Strategy {
 		}
 	}
 
-	return Jitter(s, c.jitterAmount, c.rand)
+	return Limit(Jitter(s, c.jitterAmount, c.rand), c.maxAttempts)
 }
 
 // Jitter decorates a [Strategy] so that its delays are randomly shortened,
@@ -96,3 +126,23 @@ func Jitter(s Strategy, amount float64, r Rand) Strategy {
 	}
 	return &spread{s: s, j: jitter.New(min(1, amount), r)}
 }
+
+// Limit decorates a [Strategy] with a budget of n retries, which [Attempts]
+// enforces and [MaxAttempts] reports. The delays themselves are unaffected.
+// The strategy is returned unchanged if n is zero or less. Since other
+// decorators such as [Jitter] hide the budget, Limit should be applied last.
+func Limit(s Strategy, n int) Strategy {
+	if n <= 0 {
+		return s
+	}
+	return &limited{Strategy: s, n: n}
+}
+
+// MaxAttempts reports the number of retries granted by s through [Limit] or
+// [WithMaxAttempts], or 0 if s places no limit on them.
+func MaxAttempts(s Strategy) int {
+	if l, ok := s.(*limited); ok {
+		return l.n
+	}
+	return 0
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"math/rand/v2"
 	"sync"
 	"testing"
 	"time"
@@ -169,6 +170,7 @@ func TestStrategy_ClampsAttemptNumber(t *testing.T) {
 	}{
 		{"linear", backoff.Linear(100*unit, 1000*unit)},
 		{"exponential", backoff.Exponential(100*unit, 1000*unit, 2)},
+		{"fibonacci", backoff.Fibonacci(100*unit, 1000*unit)},
 	}
 
 	for _, tt := range tests {
@@ -224,6 +226,133 @@ func TestExponential_DegradesToConstant(t *testing.T) {
 	}
 }
 
+func TestFibonacci(t *testing.T) {
+	t.Parallel()
+
+	s := backoff.Fibonacci(10*unit, 100*unit)
+	want := []time.Duration{
+		10 * unit, 10 * unit, 20 * unit, 30 * unit, 50 * unit,
+		80 * unit, 100 * unit, 100 * unit,
+	}
+
+	if got := delays(s, len(want)); !equal(got, want) {
+		t.Errorf("delays: got %v; want %v", got, want)
+	}
+}
+
+func TestFibonacci_SaturatesOnOverflow(t *testing.T) {
+	t.Parallel()
+
+	s := backoff.Fibonacci(time.Second, time.Hour)
+
+	for _, n := range []int{100, 1000, math.MaxInt} {
+		if got := s.Delay(n); got != time.Hour {
+			t.Errorf("delay(%d): got %v; want %v", n, got, time.Hour)
+		}
+	}
+}
+
+func TestFibonacci_DegradesToConstant(t *testing.T) {
+	t.Parallel()
+
+	s := backoff.Fibonacci(200*unit, 100*unit)
+	want := []time.Duration{100 * unit, 100 * unit, 100 * unit}
+
+	if got := delays(s, len(want)); !equal(got, want) {
+		t.Errorf("delays: got %v; want %v", got, want)
+	}
+}
+
+func TestFibonacci_ZeroMinDelay(t *testing.T) {
+	t.Parallel()
+
+	s := backoff.Fibonacci(0, time.Hour)
+
+	for _, n := range []int{1, 2, 100, math.MaxInt} {
+		if got := s.Delay(n); got != 0 {
+			t.Errorf("delay(%d): got %v; want 0", n, got)
+		}
+	}
+}
+
+func TestFibonacci_Jitter(t *testing.T) {
+	t.Parallel()
+
+	s := backoff.Jitter(
+		backoff.Fibonacci(10*unit, 100*unit), 0.5, &mockRand{val: 1},
+	)
+	want := []time.Duration{5 * unit, 5 * unit, 10 * unit, 15 * unit}
+
+	if got := delays(s, len(want)); !equal(got, want) {
+		t.Errorf("delays: got %v; want %v", got, want)
+	}
+	if got, want := s.MaxDelay(), 100*unit; got != want {
+		t.Errorf("max delay: got %v; want %v", got, want)
+	}
+}
+
+func TestExponentialJitter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rand backoff.Rand
+		want []time.Duration
+	}{
+		// Without randomness, each delay hits the upper end of its range.
+		{"upper bound", &mockRand{val: 0}, []time.Duration{
+			10 * unit, 30 * unit, 90 * unit, 200 * unit, 200 * unit,
+		}},
+		{"lower bound", &mockRand{val: 1}, []time.Duration{
+			10 * unit, 10 * unit, 10 * unit, 10 * unit, 10 * unit,
+		}},
+		{"midpoint", &mockRand{val: 0.5}, []time.Duration{
+			10 * unit, 20 * unit, 50 * unit, 105 * unit, 105 * unit,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := backoff.ExponentialJitter(10*unit, 200*unit, tt.rand)
+			if got := delays(s, len(tt.want)); !equal(got, tt.want) {
+				t.Errorf("delays: got %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExponentialJitter_Bounds(t *testing.T) {
+	t.Parallel()
+
+	s := backoff.ExponentialJitter(10*unit, 200*unit, nil)
+
+	if got, want := s.MinDelay(), 10*unit; got != want {
+		t.Errorf("min delay: got %v; want %v", got, want)
+	}
+	if got, want := s.MaxDelay(), 200*unit; got != want {
+		t.Errorf("max delay: got %v; want %v", got, want)
+	}
+
+	for _, n := range []int{0, 1, 2, 10, math.MaxInt} {
+		if d := s.Delay(n); d < 10*unit || d > 200*unit {
+			t.Errorf("delay(%d): got %v; want within [10, 200]", n, d)
+		}
+	}
+}
+
+func TestExponentialJitter_DegradesToConstant(t *testing.T) {
+	t.Parallel()
+
+	s := backoff.ExponentialJitter(200*unit, 100*unit, nil)
+	want := []time.Duration{100 * unit, 100 * unit, 100 * unit}
+
+	if got := delays(s, len(want)); !equal(got, want) {
+		t.Errorf("delays: got %v; want %v", got, want)
+	}
+}
+
 func TestNew_Defaults(t *testing.T) {
 	t.Parallel()
 
@@ -411,6 +540,47 @@ func TestJitter_ConcurrentUse(t *testing.T) {
 	wg.Wait()
 }
 
+func TestLockedRand(t *testing.T) {
+	t.Parallel()
+
+	// A seeded generator yields the same sequence through the adapter.
+	want := rand.New(rand.NewPCG(1, 2)).Float64()
+	r := backoff.LockedRand(rand.New(rand.NewPCG(1, 2)))
+	if got := r.Float64(); got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+
+	// Run with -race to detect unguarded access.
+	s := backoff.New(backoff.WithRand(
+		backoff.LockedRand(rand.New(rand.NewPCG(1, 2))),
+	))
+
+	var wg sync.WaitGroup
+	for range 64 {
+		wg.Go(func() {
+			for n := range 100 {
+				if d := s.Delay(n + 1); d < 0 {
+					t.Errorf("delay: got %v; want a non-negative duration", d)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestLockedRand_Nil(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("should have panicked")
+		}
+	}()
+
+	backoff.LockedRand(nil)
+}
+
 func TestCount(t *testing.T) {
 	t.Parallel()
 
@@ -443,6 +613,78 @@ func TestCount(t *testing.T) {
 	}
 }
 
+func TestCount_Exhausted(t *testing.T) {
+	t.Parallel()
+
+	s := backoff.New(
+		backoff.WithMinDelay(0),
+		backoff.WithMaxDelay(unit),
+		backoff.WithMaxAttempts(2),
+	)
+	a := backoff.Count(s)
+
+	for i := range 2 {
+		if a.Exhausted() {
+			t.Fatalf("attempt %d: should not be exhausted", i+1)
+		}
+		if err := a.Wait(t.Context()); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+	}
+
+	if !a.Exhausted() {
+		t.Error("should be exhausted")
+	}
+	if err := a.Wait(t.Context()); !errors.Is(err, backoff.ErrExhausted) {
+		t.Errorf("got %v; want %v", err, backoff.ErrExhausted)
+	}
+	if n := a.Count(); n != 2 {
+		t.Errorf("count: got %d; want 2", n)
+	}
+
+	a.Reset()
+	if a.Exhausted() {
+		t.Error("should not be exhausted after reset")
+	}
+}
+
+func TestLimit(t *testing.T) {
+	t.Parallel()
+
+	base := backoff.Linear(100*unit, 1000*unit)
+
+	tests := []struct {
+		name string
+		s    backoff.Strategy
+		want int
+	}{
+		{"unlimited", base, 0},
+		{"limited", backoff.Limit(base, 3), 3},
+		{"zero", backoff.Limit(base, 0), 0},
+		{"negative", backoff.Limit(base, -1), 0},
+		{"option", backoff.New(backoff.WithMaxAttempts(5)), 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := backoff.MaxAttempts(tt.s); got != tt.want {
+				t.Errorf("max attempts: got %d; want %d", got, tt.want)
+			}
+		})
+	}
+
+	// The delays themselves are unaffected by the limit.
+	s := backoff.Limit(base, 1)
+	if got, want := delays(s, 3), delays(base, 3); !equal(got, want) {
+		t.Errorf("delays: got %v; want %v", got, want)
+	}
+	if got, want := s.MaxDelay(), base.MaxDelay(); got != want {
+		t.Errorf("max delay: got %v; want %v", got, want)
+	}
+}
+
 func TestCount_NilStrategy(t *testing.T) {
 	t.Parallel()
 
@@ -36,6 +36,7 @@ type config struct {
 	growthFactor float64       // exponential multiplier per attempt
 	jitterAmount float64       // fraction of the delay subject to jitter
 	rand         Rand          // source of randomness for jitter
+	maxAttempts  int           // retries granted, if positive
 }
 
 // Option customizes the behavior of a backoff [Strategy].
@@ -92,8 +93,23 @@ func WithJitterAmount(p float64) Option {
 	}
 }
 
-// WithRand sets the source of randomness used to compute jitter. If not
-// specified or nil, a shared, auto-seeded generator is used.
+// WithMaxAttempts grants a budget of n retries, following the terminology of
+// [Strategy.Delay], where attempt 1 is the first retry. The strategy keeps
+// supplying delays beyond the budget, but an [Attempts] counter reports itself
+// as exhausted once it has handed out n delays, and consumers such as the
+// retry transport stop early. See [Limit]. If n is zero or less, which is the
+// default, no limit is imposed.
+func WithMaxAttempts(n int) Option {
+	return func(c *config) {
+		c.maxAttempts = n
+	}
+}
+
+// WithRand sets the source of randomness used to compute jitter. The source
+// must be safe for concurrent use, since the strategy may be shared by many
+// goroutines; see [LockedRand] for wrapping a [math/rand/v2.Rand]. If not
+// specified or nil, a shared, auto-seeded generator is used, which is safe
+// for concurrent use.
 func WithRand(r Rand) Option {
 	return func(c *config) {
 		if r != nil {
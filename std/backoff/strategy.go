@@ -149,6 +149,104 @@ func (e *exponential) MaxDelay() time.Duration { return e.maxDelay }
 
 var _ Strategy = (*exponential)(nil)
 
+// fibonacci is a [Strategy] implementation that increases the delay along the
+// Fibonacci sequence.
+type fibonacci struct {
+	minDelay time.Duration // unit multiplied by the Fibonacci numbers
+	maxDelay time.Duration // ceiling for the backoff duration
+}
+
+// Fibonacci produces a [Strategy] whose delays are the Fibonacci multiples of
+// minDelay, so that the first attempts wait for 1, 1, 2, 3, 5, 8, ... times
+// minDelay, capped at maxDelay. It grows faster than [Linear] but slower than
+// [Exponential] with its default factor of two. Negative durations are treated
+// as zero. If minDelay is not less than maxDelay, the result is equivalent to
+// [Constant] at maxDelay. If minDelay is zero, every multiple of it is zero as
+// well, so the result is equivalent to [Constant] at zero.
+func Fibonacci(minDelay, maxDelay time.Duration) Strategy {
+	minDelay, maxDelay = max(0, minDelay), max(0, maxDelay)
+	if minDelay >= maxDelay {
+		return &constant{delay: maxDelay}
+	}
+	if minDelay == 0 {
+		// The sequence would never leave zero to reach the cap.
+		return &constant{delay: 0}
+	}
+	return &fibonacci{minDelay: minDelay, maxDelay: maxDelay}
+}
+
+// Delay returns the backoff duration preceding attempt n.
+func (f *fibonacci) Delay(n int) time.Duration {
+	// The sequence is advanced in floating point until it reaches the cap,
+	// which bounds the loop even for very large attempt counts.
+	prev, curr := 0.0, float64(f.minDelay)
+	for i := 1; i < n && curr < float64(f.maxDelay); i++ {
+		prev, curr = curr, prev+curr
+	}
+	return clamp(curr, f.minDelay, f.maxDelay)
+}
+
+// MinDelay returns the minimum delay configured for this [fibonacci] strategy.
+func (f *fibonacci) MinDelay() time.Duration { return f.minDelay }
+
+// MaxDelay returns the maximum delay configured for this [fibonacci] strategy.
+func (f *fibonacci) MaxDelay() time.Duration { return f.maxDelay }
+
+var _ Strategy = (*fibonacci)(nil)
+
+// exponentialJitter is a [Strategy] implementation that draws each delay at
+// random from a range growing threefold per attempt.
+type exponentialJitter struct {
+	minDelay time.Duration  // lower bound of every range
+	maxDelay time.Duration  // ceiling for the backoff duration
+	j        *jitter.Jitter // full jitter used to draw from the range
+}
+
+// ExponentialJitter produces a [Strategy] that applies full jitter to an
+// exponential backoff with a base of three: attempt n waits for a random
+// duration between minDelay and minDelay*3^(n-1), capped at maxDelay. Unlike
+// [Jitter], which shortens a delay by at most a fraction of it, the draw spans
+// the whole range, which spreads contending clients more evenly.
+//
+// It approximates the "decorrelated jitter" algorithm, which draws from
+// random(minDelay, prev*3), by using the upper bound of the previous range in
+// place of the previous delay; strategies are stateless, so prev is unknown.
+//
+// Negative durations are treated as zero. If minDelay is not less than
+// maxDelay, the result is equivalent to [Constant] at maxDelay. If r is nil, a
+// shared, auto-seeded generator is used.
+func ExponentialJitter(minDelay, maxDelay time.Duration, r Rand) Strategy {
+	minDelay, maxDelay = max(0, minDelay), max(0, maxDelay)
+	if minDelay >= maxDelay {
+		return &constant{delay: maxDelay}
+	}
+	return &exponentialJitter{
+		minDelay: minDelay,
+		maxDelay: maxDelay,
+		j:        jitter.New(1, r),
+	}
+}
+
+// Delay returns a random backoff duration preceding attempt n.
+func (e *exponentialJitter) Delay(n int) time.Duration {
+	if n < 1 {
+		n = 1
+	}
+	f := float64(e.minDelay) * math.Pow(3, float64(n-1))
+	hi := clamp(f, e.minDelay, e.maxDelay)
+	return e.minDelay + e.j.Apply(hi-e.minDelay)
+}
+
+// MinDelay returns the minimum delay configured for this [exponentialJitter]
+// strategy.
+func (e *exponentialJitter) MinDelay() time.Duration { return e.minDelay }
+
+// MaxDelay returns the maximum delay configured for this [exponentialJitter]
+// strategy.
+func (e *exponentialJitter) MaxDelay() time.Duration { return e.maxDelay }
+
+var _ Strategy = (*exponentialJitter)(nil)
+
 // spread decorates a [Strategy] with jitter in order to scatter retry attempts
 // over time.
 type spread struct {
@@ -174,3 +272,11 @@ func (s *spread) MaxDelay() time.Duration {
 }
 
 var _ Strategy = (*spread)(nil)
+
+// limited decorates a [Strategy] with a maximum number of retries.
+type limited struct {
+	Strategy     // underlying strategy supplying the delays
+	n        int // maximum number of retries
+}
+
+var _ Strategy = (*limited)(nil)
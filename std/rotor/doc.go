@@ -42,4 +42,12 @@
 //
 //	// Each call returns a randomly selected item.
 //	k := r.Next() // e.g. "key-3"
+//
+// Example: Pinned selection
+//
+//	keys := []string{"newest", "older", "oldest"}
+//	r := rotor.New(rotor.First, keys)
+//
+//	// Each call returns the first item.
+//	k := r.Next() // "newest"
 package rotor
@@ -28,6 +28,10 @@ const (
 	Sequential Strategy = iota
 	// Random strategy chooses the next element randomly.
 	Random
+	// First strategy always picks the first element. This is useful to pin
+	// selection to a preferred item (e.g., the newest key) while keeping the
+	// remaining items around for other purposes.
+	First
 )
 
 // strategy defines how the next element index is selected.
@@ -97,12 +101,13 @@ type rotor[E any] struct {
 //
 // It makes a defensive copy of the provided items slice to ensure immutability.
 // This function panics if the items slice is empty. If the slice contains
-// exactly one item, an optimized [Rotor] implementation will be created.
+// exactly one item, or if the strategy is [First], an optimized [Rotor]
+// implementation will be created.
 func New[E any](t Strategy, items []E) Rotor[E] {
 	if len(items) == 0 {
 		panic("items slice must not be empty")
 	}
-	if len(items) == 1 {
+	if len(items) == 1 || t == First {
 		return &singleton[E]{item: items[0]}
 	}
 	c := make([]E, len(items))
@@ -147,6 +147,19 @@ func TestRotor_Next_Sequential(t *testing.T) {
 	})
 }
 
+func TestRotor_Next_First(t *testing.T) {
+	t.Parallel()
+
+	items := []string{"a", "b", "c"}
+	r := rotor.New(rotor.First, items)
+
+	for range 4 {
+		if got, want := r.Next(), "a"; got != want {
+			t.Errorf("got %q; want %q", got, want)
+		}
+	}
+}
+
 func TestRotor_Next_Concurrent(t *testing.T) {
 	t.Parallel()
 
@@ -19,7 +19,7 @@
 // variables. The variable name is derived by converting the field's name to
 // uppercase SNAKE_CASE (e.g., a field named APIKey maps to API_KEY).
 // This behavior can be customized or disabled on a per-field basis using
-// struct tags.
+// struct tags, or replaced altogether using [WithNameMapper].
 //
 // # Usage
 //
@@ -46,6 +46,22 @@
 //	}
 //	// Use the configuration to bootstrap your application...
 //
+// Rules that span multiple fields can be expressed by implementing [Validator]
+// on the configuration struct. Its Validate method runs after all fields have
+// been populated.
+//
+// To layer defaults from a dotenv file beneath the actual environment, use
+// [FileLookup] together with [ChainLookups]:
+//
+//	file, err := env.FileLookup(".env")
+//	err = env.Unmarshal(&cfg, env.WithLookup(
+//		env.ChainLookups(os.LookupEnv, file),
+//	))
+//
+// To document the variables a configuration struct consumes, use [Describe]:
+//
+//	vars, err := env.Describe(&cfg)
+//
 // # Options
 //
 // The behavior of the unmarshaler is controlled by the env struct field tag.
@@ -69,6 +85,11 @@
 //
 //	APIKey string `env:",required"`
 //
+// Option "optional": Exempts the variable from [WithRequireAll], which
+// otherwise treats every variable without a default as required.
+//
+//	Proxy string `env:",optional"`
+//
 // Option "prefix": For nested struct fields, this overrides the default
 // prefix. By default, the prefix is the field's name in SNAKE_CASE followed by
 // an underscore. It can be set to an empty string to omit the prefix entirely.
@@ -86,6 +107,23 @@
 //
 //	Hosts []string `env:",split:';'"`
 //
+// Slices of structs are read from indexed variables, where the index follows
+// the field's prefix (e.g., UPSTREAM_0_HOST, UPSTREAM_0_PORT, UPSTREAM_1_HOST).
+// Elements are collected from index 0 until the first index for which no
+// variable is set.
+//
+//	Upstream []struct{ Host string; Port int }
+//
+// Booleans accept the spellings understood by [strconv.ParseBool], as well as
+// "yes", "no", "y", "n", "on", and "off", regardless of case.
+//
+// Maps are parsed from a list of key-value pairs. Option "pairs" specifies
+// the delimiter between entries, which defaults to the "split" delimiter.
+// Option "delim" specifies the delimiter between key and value, which defaults
+// to a colon. Keys and values are parsed like any other field.
+//
+//	Features map[string]bool `env:",pairs:';',delim:'='"`
+//
 // Option "format": Provides a format specifier for special types. For
 // [time.Time] it can be a Go-compliant layout string (e.g., "2006-01-02") or
 // one of the predefined constants "unix", "dateTime", "date", and "time".
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 
 	"github.com/deep-rent/nexus/dat/bind"
 	"github.com/deep-rent/nexus/std/ascii"
@@ -41,6 +42,31 @@ var binder = bind.New(
 	bind.WithCache(true),
 )
 
+// strict is the counterpart of binder used with [WithRequireAll].
+var strict = bind.New(
+	"env",
+	bind.WithTransformer(snake.ToUpper),
+	bind.WithCache(true),
+	bind.WithRequireAll(true),
+)
+
+// binderFor returns the binder matching the options in cfg. The cached
+// binders serve the default naming convention; a custom name mapper gets a
+// binder of its own, since the derived keys are part of the cached metadata.
+func binderFor(cfg *config) *bind.Binder {
+	if cfg.NameMapper == nil {
+		if cfg.RequireAll {
+			return strict
+		}
+		return binder
+	}
+	return bind.New(
+		"env",
+		bind.WithTransformer(cfg.NameMapper),
+		bind.WithRequireAll(cfg.RequireAll),
+	)
+}
+
 type source struct {
 	lookup Lookup
 }
@@ -54,6 +80,18 @@ func (s source) Lookup(key string) ([]string, bool) {
 
 var _ bind.Source = (*source)(nil)
 
+// expandingSource is a source that substitutes variable references in every
+// value, as enabled by [WithExpand].
+type expandingSource struct {
+	source
+}
+
+func (s expandingSource) Expand(val string) (string, error) {
+	return Expand(val, WithLookup(s.lookup))
+}
+
+var _ bind.Expander = (*expandingSource)(nil)
+
 // Unmarshal populates the fields of a struct with values from environment
 // variables. The given value v must be a non-nil pointer to a struct.
 //
@@ -65,7 +103,9 @@ var _ bind.Source = (*source)(nil)
 //
 // Every problem found is reported together, so a misconfigured environment
 // can be corrected in one pass rather than one variable per attempt. Use
-// [errors.Join] semantics to inspect the result.
+// [errors.Join] semantics to inspect the result. If all fields were populated
+// successfully and the struct implements [Validator], its Validate method is
+// invoked last to check cross-field rules.
 func Unmarshal[T any](v *T, opts ...Option) error {
 	cfg := config{
 		Lookup: os.LookupEnv,
@@ -74,7 +114,80 @@ func Unmarshal[T any](v *T, opts ...Option) error {
 		opt(&cfg)
 	}
 
-	return binder.Bind(v, cfg.Prefix, source{cfg.Lookup})
+	b := binderFor(&cfg)
+	var src bind.Source = source{cfg.Lookup}
+	if cfg.Expand {
+		src = expandingSource{source{cfg.Lookup}}
+	}
+	if err := b.Bind(v, cfg.Prefix, src); err != nil {
+		return err
+	}
+	return Validate(v)
+}
+
+// Validator is implemented by configuration structs that need to enforce
+// rules spanning multiple fields, such as a certificate path that is only
+// required if TLS is enabled. Expressing these in code keeps the struct tags
+// free of a conditional mini-language.
+type Validator interface {
+	// Validate reports whether the populated configuration is consistent.
+	Validate() error
+}
+
+// Validate invokes the Validate method of v if it implements [Validator], and
+// returns nil otherwise. [Unmarshal] calls it automatically once all fields
+// have been populated without error, so it only needs to be called directly
+// if the struct is modified or assembled by other means.
+func Validate(v any) error {
+	if val, ok := v.(Validator); ok {
+		return val.Validate()
+	}
+	return nil
+}
+
+// VarInfo describes an environment variable consumed by [Unmarshal].
+type VarInfo struct {
+	// Name is the fully-qualified variable name, including all prefixes.
+	Name string
+	// Field is the dot-separated path of the struct field it is bound to.
+	Field string
+	// Type is the name of the field's Go type.
+	Type string
+	// Default is the default value, or an empty string if there is none.
+	Default string
+	// Required indicates whether [Unmarshal] fails if the variable is unset.
+	Required bool
+}
+
+// Describe lists every environment variable that [Unmarshal] would consume
+// for the given struct (or pointer to a struct), in field order. It walks the
+// struct exactly like [Unmarshal] does and honors the same options, but it
+// does not read any variables. This is useful for documenting the expected
+// configuration of an application.
+func Describe(v any, opts ...Option) ([]VarInfo, error) {
+	cfg := config{
+		Lookup: os.LookupEnv,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	b := binderFor(&cfg)
+	ds, err := b.Describe(reflect.TypeOf(v), cfg.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]VarInfo, 0, len(ds))
+	for _, d := range ds {
+		infos = append(infos, VarInfo{
+			Name:     d.Key,
+			Field:    d.Field,
+			Type:     d.Type.String(),
+			Default:  d.Default,
+			Required: d.Required,
+		})
+	}
+	return infos, nil
 }
 
 // Expand substitutes environment variables in a string.
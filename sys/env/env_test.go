@@ -15,6 +15,7 @@
 package env_test
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
@@ -59,6 +60,351 @@ func TestUnmarshal_Errors(t *testing.T) {
 	})
 }
 
+func TestUnmarshal_RequireAll(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Host  string
+		Port  int    `env:",default:8080"`
+		Proxy string `env:",optional"`
+		User  string
+	}
+
+	lookup := func(vars map[string]string) env.Option {
+		return env.WithLookup(func(k string) (string, bool) {
+			v, ok := vars[k]
+			return v, ok
+		})
+	}
+
+	t.Run("all set", func(t *testing.T) {
+		t.Parallel()
+		var cfg config
+		err := env.Unmarshal(
+			&cfg,
+			env.WithRequireAll(),
+			lookup(map[string]string{"HOST": "localhost", "USER": "admin"}),
+		)
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		want := config{Host: "localhost", Port: 8080, User: "admin"}
+		if cfg != want {
+			t.Errorf("got %+v; want %+v", cfg, want)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Parallel()
+		var cfg config
+		err := env.Unmarshal(
+			&cfg,
+			env.WithRequireAll(),
+			lookup(map[string]string{"HOST": "localhost"}),
+		)
+		if err == nil {
+			t.Fatal("should have returned an error")
+		}
+		if !strings.Contains(err.Error(), "USER") {
+			t.Errorf("want match for %q; got %q", "USER", err)
+		}
+		for _, key := range []string{"HOST", "PORT", "PROXY"} {
+			if strings.Contains(err.Error(), `"`+key+`"`) {
+				t.Errorf("should not have complained about %q: %q", key, err)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		var cfg config
+		if err := env.Unmarshal(&cfg, lookup(nil)); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+	})
+
+	t.Run("conflicting options", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct {
+			V string `env:",required,optional"`
+		}
+		if err := env.Unmarshal(&cfg, lookup(nil)); err == nil {
+			t.Fatal("should have returned an error")
+		}
+	})
+}
+
+type tlsConfig struct {
+	TLSEnabled bool
+	TLSCert    string
+}
+
+func (c *tlsConfig) Validate() error {
+	if c.TLSEnabled && c.TLSCert == "" {
+		return errors.New("TLS_CERT is required when TLS is enabled")
+	}
+	return nil
+}
+
+var _ env.Validator = (*tlsConfig)(nil)
+
+func TestUnmarshal_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		vars    map[string]string
+		wantErr bool
+	}{
+		{"disabled", map[string]string{}, false},
+		{
+			"enabled with cert",
+			map[string]string{"TLS_ENABLED": "true", "TLS_CERT": "/cert.pem"},
+			false,
+		},
+		{
+			"enabled without cert",
+			map[string]string{"TLS_ENABLED": "true"},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var cfg tlsConfig
+			err := env.Unmarshal(&cfg, env.WithLookup(
+				func(k string) (string, bool) {
+					v, ok := tt.vars[k]
+					return v, ok
+				},
+			))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("should have returned an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("should not have returned an error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	if err := env.Validate(&tlsConfig{TLSEnabled: true}); err == nil {
+		t.Error("should have returned an error")
+	}
+	if err := env.Validate(&struct{}{}); err != nil {
+		t.Errorf("should not have returned an error: %v", err)
+	}
+}
+
+func TestUnmarshal_Expand(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]string{
+		"HOST": "example.com",
+		"PORT": "8443",
+		"URL":  "https://${HOST}:${PORT}",
+		"LOOP": "$LOOP",
+	}
+	lookup := env.WithLookup(func(k string) (string, bool) {
+		v, ok := vars[k]
+		return v, ok
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct {
+			URL  string
+			Loop string
+			Base string `env:",default:'http://$HOST'"`
+		}
+		if err := env.Unmarshal(&cfg, lookup, env.WithExpand()); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		if got, want := cfg.URL, "https://example.com:8443"; got != want {
+			t.Errorf("url: got %q; want %q", got, want)
+		}
+		if got, want := cfg.Loop, "$LOOP"; got != want {
+			t.Errorf("loop: got %q; want %q", got, want)
+		}
+		if got, want := cfg.Base, "http://example.com"; got != want {
+			t.Errorf("base: got %q; want %q", got, want)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct{ URL string }
+		if err := env.Unmarshal(&cfg, lookup); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		if got, want := cfg.URL, "https://${HOST}:${PORT}"; got != want {
+			t.Errorf("got %q; want %q", got, want)
+		}
+	})
+
+	t.Run("unset reference", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct {
+			V string `env:",default:'${MISSING}'"`
+		}
+		err := env.Unmarshal(&cfg, lookup, env.WithExpand())
+		if err == nil {
+			t.Fatal("should have returned an error")
+		}
+		if !strings.Contains(err.Error(), "MISSING") {
+			t.Errorf("want match for %q; got %q", "MISSING", err)
+		}
+	})
+}
+
+func TestUnmarshal_Indexed(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]string{
+		"UPSTREAM_0_HOST": "a.example.com",
+		"UPSTREAM_0_PORT": "8080",
+		"UPSTREAM_1_HOST": "b.example.com",
+		"UPSTREAM_3_HOST": "d.example.com",
+	}
+	lookup := env.WithLookup(func(k string) (string, bool) {
+		v, ok := vars[k]
+		return v, ok
+	})
+
+	type upstream struct {
+		Host string
+		Port int `env:",default:80"`
+	}
+	var cfg struct {
+		Upstream []upstream
+	}
+	if err := env.Unmarshal(&cfg, lookup); err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	want := []upstream{{"a.example.com", 8080}, {"b.example.com", 80}}
+	if !reflect.DeepEqual(cfg.Upstream, want) {
+		t.Errorf("got %+v; want %+v", cfg.Upstream, want)
+	}
+}
+
+func TestUnmarshal_NameMapper(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]string{
+		"app.maxconns":    "8",
+		"app.db.hostname": "db.example.com",
+		"PORT":            "5432",
+	}
+	lookup := env.WithLookup(func(k string) (string, bool) {
+		v, ok := vars[k]
+		return v, ok
+	})
+	mapper := env.WithNameMapper(strings.ToLower)
+
+	type db struct {
+		Hostname string
+		Port     int `env:"PORT"`
+	}
+	var cfg struct {
+		MaxConns int
+		DB       db `env:",prefix:'db.'"`
+	}
+	err := env.Unmarshal(&cfg, lookup, mapper, env.WithPrefix("app."))
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	if got, want := cfg.MaxConns, 8; got != want {
+		t.Errorf("max conns: got %d; want %d", got, want)
+	}
+	if got, want := cfg.DB.Hostname, "db.example.com"; got != want {
+		t.Errorf("hostname: got %q; want %q", got, want)
+	}
+	if got := cfg.DB.Port; got != 0 {
+		t.Errorf("port: got %d; want 0", got)
+	}
+
+	t.Run("default unaffected", func(t *testing.T) {
+		t.Parallel()
+		var cfg struct{ MaxConns int }
+		vars := env.WithLookup(func(k string) (string, bool) {
+			return "3", k == "MAX_CONNS"
+		})
+		if err := env.Unmarshal(&cfg, vars); err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		if got, want := cfg.MaxConns, 3; got != want {
+			t.Errorf("got %d; want %d", got, want)
+		}
+	})
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	type proxy struct {
+		URL string `env:",required"`
+	}
+	type config struct {
+		Host    string        `env:",required"`
+		Port    int           `env:",default:8080"`
+		Timeout time.Duration `env:",unit:s"`
+		Proxy   *proxy        `env:",prefix:'HTTP_PROXY_'"`
+		Ignored int           `env:"-"`
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		got, err := env.Describe(&config{}, env.WithPrefix("APP_"))
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		want := []env.VarInfo{
+			{Name: "APP_HOST", Field: "Host", Type: "string", Required: true},
+			{Name: "APP_PORT", Field: "Port", Type: "int", Default: "8080"},
+			{Name: "APP_TIMEOUT", Field: "Timeout", Type: "time.Duration"},
+			{
+				Name:     "APP_HTTP_PROXY_URL",
+				Field:    "Proxy.URL",
+				Type:     "string",
+				Required: true,
+			},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v; want %+v", got, want)
+		}
+	})
+
+	t.Run("require all", func(t *testing.T) {
+		t.Parallel()
+		got, err := env.Describe(config{}, env.WithRequireAll())
+		if err != nil {
+			t.Fatalf("should not have returned an error: %v", err)
+		}
+		for _, info := range got {
+			if want := info.Default == ""; info.Required != want {
+				t.Errorf("%s: got required %t; want %t",
+					info.Name, info.Required, want)
+			}
+		}
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		t.Parallel()
+		if _, err := env.Describe(42); err == nil {
+			t.Fatal("should have returned an error")
+		}
+	})
+}
+
 func TestExpand(t *testing.T) {
 	t.Parallel()
 
@@ -241,3 +587,44 @@ func TestUnmarshal_CollectsAllErrors(t *testing.T) {
 		}
 	}
 }
+
+// Invalid values and missing variables, including those of nested sections,
+// are surfaced side by side rather than one after the other.
+func TestUnmarshal_CollectsMixedErrors(t *testing.T) {
+	t.Parallel()
+
+	var cfg struct {
+		Host string `env:",required"`
+		Port int
+		DB   struct {
+			User    string        `env:",required"`
+			Timeout time.Duration `env:",unit:s"`
+		}
+	}
+
+	vars := map[string]string{
+		"PORT":       "eighty",
+		"DB_TIMEOUT": "soon",
+	}
+	err := env.Unmarshal(&cfg, env.WithLookup(func(k string) (string, bool) {
+		v, ok := vars[k]
+		return v, ok
+	}))
+	if err == nil {
+		t.Fatal("should have returned an error")
+	}
+
+	for _, want := range []string{"HOST", "PORT", "DB_USER", "DB_TIMEOUT"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("want match for %q; got %q", want, err)
+		}
+	}
+
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		t.Fatalf("should have returned a joined error; got %T", err)
+	}
+	if got := len(joined.Unwrap()); got < 3 {
+		t.Errorf("got %d wrapped errors; want at least 3", got)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FileLookup reads a dotenv file and returns a [Lookup] that serves the
+// variables defined therein. It is meant to be passed to [WithLookup], usually
+// in combination with [ChainLookups] so that the actual environment takes
+// precedence over the file:
+//
+//	file, err := env.FileLookup(".env")
+//	if err != nil { /* handle error */ }
+//	err = env.Unmarshal(&cfg, env.WithLookup(
+//		env.ChainLookups(os.LookupEnv, file),
+//	))
+//
+// Each non-empty line must have the form KEY=VALUE, optionally preceded by
+// the "export" keyword. Lines starting with # are comments, as is anything
+// following a # that is separated from an unquoted value by whitespace.
+// Values may be enclosed in single or double quotes to preserve surrounding
+// whitespace or # characters. Within double quotes, the escape sequences \n,
+// \r, \t, \", and \\ are recognized. Later definitions of the same key
+// override earlier ones.
+func FileLookup(path string) (Lookup, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+	vars, err := parseFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}, nil
+}
+
+// ChainLookups combines multiple [Lookup] functions into one. The lookups are
+// consulted in order, and the first one that reports a variable as present
+// wins. Nil lookups are skipped.
+func ChainLookups(lookups ...Lookup) Lookup {
+	return func(key string) (string, bool) {
+		for _, lookup := range lookups {
+			if lookup == nil {
+				continue
+			}
+			if v, ok := lookup(key); ok {
+				return v, true
+			}
+		}
+		return "", false
+	}
+}
+
+// parseFile parses the contents of a dotenv file into a map of variables.
+func parseFile(data []byte) (map[string]string, error) {
+	vars := make(map[string]string)
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n)
+		}
+		k = strings.TrimSpace(k)
+		if k == "" {
+			return nil, fmt.Errorf("line %d: empty key", n)
+		}
+		v, err := parseValue(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		vars[k] = v
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// parseValue interprets the raw value of a dotenv line, handling quotes,
+// escape sequences, and trailing comments.
+func parseValue(v string) (string, error) {
+	if v == "" {
+		return "", nil
+	}
+	q := v[0]
+	if q != '"' && q != '\'' {
+		// Unquoted values end at the first comment.
+		if i := strings.Index(v, " #"); i >= 0 {
+			v = v[:i]
+		}
+		if i := strings.Index(v, "\t#"); i >= 0 {
+			v = v[:i]
+		}
+		return strings.TrimSpace(v), nil
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(v); i++ {
+		c := v[i]
+		switch {
+		case c == q:
+			rest := strings.TrimSpace(v[i+1:])
+			if rest != "" && rest[0] != '#' {
+				return "", fmt.Errorf("unexpected characters after quote: %q",
+					rest)
+			}
+			return b.String(), nil
+		case c == '\\' && q == '"' && i+1 < len(v):
+			i++
+			switch e := v[i]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\':
+				b.WriteByte(e)
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", errors.New("unterminated quoted value")
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2025-present deep.rent GmbH (https://deep.rent)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/deep-rent/nexus/sys/env"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing file: should not have returned an error: %v", err)
+	}
+	return path
+}
+
+func TestFileLookup(t *testing.T) {
+	t.Parallel()
+
+	path := writeFile(t, `
+# Database settings
+DB_HOST=localhost
+export DB_PORT = 5432
+DB_USER="admin" # inline comment
+DB_PASS='p#ss w0rd'
+DB_NAME=app # trailing comment
+GREETING="hello\nworld \"quoted\""
+EMPTY=
+OVERRIDE=first
+OVERRIDE=second
+`)
+
+	lookup, err := env.FileLookup(path)
+	if err != nil {
+		t.Fatalf("should not have returned an error: %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"DB_HOST", "localhost"},
+		{"DB_PORT", "5432"},
+		{"DB_USER", "admin"},
+		{"DB_PASS", "p#ss w0rd"},
+		{"DB_NAME", "app"},
+		{"GREETING", "hello\nworld \"quoted\""},
+		{"EMPTY", ""},
+		{"OVERRIDE", "second"},
+	}
+	for _, tt := range tests {
+		got, ok := lookup(tt.key)
+		if !ok {
+			t.Errorf("%s: should have been found", tt.key)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q; want %q", tt.key, got, tt.want)
+		}
+	}
+
+	if _, ok := lookup("MISSING"); ok {
+		t.Error("MISSING: should not have been found")
+	}
+}
+
+func TestFileLookup_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"missing equals", "FOO"},
+		{"empty key", "=bar"},
+		{"unterminated quote", `FOO="bar`},
+		{"garbage after quote", `FOO="bar" baz`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := env.FileLookup(writeFile(t, tt.content)); err == nil {
+				t.Fatal("should have returned an error")
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "missing.env")
+		if _, err := env.FileLookup(path); err == nil {
+			t.Fatal("should have returned an error")
+		}
+	})
+}
+
+func TestChainLookups(t *testing.T) {
+	t.Parallel()
+
+	first := func(k string) (string, bool) {
+		if k == "A" {
+			return "first", true
+		}
+		return "", false
+	}
+	second := func(k string) (string, bool) {
+		switch k {
+		case "A", "B":
+			return "second", true
+		}
+		return "", false
+	}
+	lookup := env.ChainLookups(first, nil, second)
+
+	tests := []struct {
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{"A", "first", true},
+		{"B", "second", true},
+		{"C", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := lookup(tt.key)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: got %q, %t; want %q, %t",
+				tt.key, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
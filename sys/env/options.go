@@ -37,10 +37,59 @@ func WithLookup(lookup Lookup) Option {
 	}
 }
 
+// WithRequireAll treats every field as required, so that [Unmarshal] fails
+// if any variable is unset and the field has no default value. Fields can opt
+// out of this with the "optional" tag option. This is useful for strict
+// production configurations, where a forgotten variable should be caught
+// early rather than silently falling back to the zero value.
+func WithRequireAll() Option {
+	return func(c *config) {
+		c.RequireAll = true
+	}
+}
+
+// WithExpand enables the substitution of variable references in values during
+// [Unmarshal], following the syntax of [Expand]. For example, with HOST and
+// PORT set, a variable URL=https://${HOST}:${PORT} is expanded before it is
+// parsed into its field. Default values from struct tags are expanded as well.
+// References are resolved through the configured [Lookup] by their literal
+// names, without applying the prefix set by [WithPrefix]. Substituted values
+// are not expanded again, which rules out infinite recursion. An unset
+// reference is reported as an error for the field.
+func WithExpand() Option {
+	return func(c *config) {
+		c.Expand = true
+	}
+}
+
+// WithNameMapper overrides how variable names are derived from field names,
+// which defaults to uppercase SNAKE_CASE. The mapper applies to every field
+// without an explicit name in its tag, as well as to the default prefixes of
+// nested structs. It only sees a single field name at a time: the segments
+// of a nested path are mapped one by one and then joined with an underscore,
+// so a field Port nested under Database maps to mapper("Database") + "_" +
+// mapper("Port"). Use the "prefix" tag option to join them differently. This
+// saves tagging each field individually when a set of variables follows a
+// uniform but non-standard naming convention. A nil mapper is ignored.
+func WithNameMapper(mapper func(fieldName string) string) Option {
+	return func(c *config) {
+		if mapper != nil {
+			c.NameMapper = mapper
+		}
+	}
+}
+
 // config holds configuration options for environment variable processing.
 type config struct {
 	// Prefix is a common prefix for all environment variable keys.
 	Prefix string
 	// Lookup is the injectable callback for variable lookup.
 	Lookup Lookup
+	// RequireAll indicates whether all fields are required by default.
+	RequireAll bool
+	// Expand indicates whether variable references in values are expanded.
+	Expand bool
+	// NameMapper derives variable names from field names, or is nil for the
+	// default SNAKE_CASE convention.
+	NameMapper func(string) string
 }